@@ -16,6 +16,8 @@ type Config struct {
 	Dir      string
 	Format   string
 	Output   string
+	OpenAPI  string
+	Offline  bool
 
 	Usage func()
 }
@@ -42,6 +44,12 @@ func getConfig() (cfg Config, cmd string, err error) {
 	app.Flag("output", "will set output destination").
 		StringVar(&cfg.Output)
 
+	app.Flag("openapi", "will set openapi version to produce (3.0.x, 3.1.x)").
+		StringVar(&cfg.OpenAPI)
+
+	app.Flag("offline", "will resolve remote references only from cache").
+		BoolVar(&cfg.Offline)
+
 	// Parse
 	cmd, err = app.Parse(os.Args[1:])
 	return
@@ -93,6 +101,26 @@ func (ff Config) full() (cfg config.Config, err error) {
 		cfg.Format = ff.Format
 	}
 
+	// version of openapi
+	if len(ff.OpenAPI) > 0 {
+		cfg.OpenAPI = ff.OpenAPI
+	}
+
+	// remote references, paths are relative to config
+	base := wd
+	if len(cfg.FilePath) > 0 {
+		base = filepath.Dir(cfg.FilePath)
+	}
+	if len(cfg.Remote.CacheDir) > 0 {
+		cfg.Remote.CacheDir = toAbsPath(cfg.Remote.CacheDir, base)
+	}
+	if len(cfg.Remote.LockFile) > 0 {
+		cfg.Remote.LockFile = toAbsPath(cfg.Remote.LockFile, base)
+	}
+	if ff.Offline {
+		cfg.Remote.Offline = true
+	}
+
 	// output destination
 	output := "stdout"
 	if len(cfg.Output) > 0 {
@@ -6,7 +6,7 @@ import (
 	"github.com/buypal/oapi-go"
 	"github.com/buypal/oapi-go/internal/logging"
 	"github.com/buypal/oapi-go/internal/oapi/config"
-	"github.com/buypal/oapi-go/internal/oapi/spec"
+	"github.com/buypal/oapi-go/spec"
 )
 
 func scan(ctx context.Context, log logging.Printer, cfg config.Config) (oapi.OAPI, error) {
@@ -24,6 +24,42 @@ func scan(ctx context.Context, log logging.Printer, cfg config.Config) (oapi.OAP
 		opts = append(opts, oapi.WithOverride(cfg.Overrides))
 	}
 
+	if len(cfg.Types) > 0 {
+		opts = append(opts, oapi.WithTypes(cfg.Types))
+	}
+
+	if cfg.EnumStringer {
+		opts = append(opts, oapi.WithEnumStringer(true))
+	}
+
+	if cfg.InferRequired {
+		opts = append(opts, oapi.WithInferRequired(true))
+	}
+
+	if cfg.ComposeEmbedded {
+		opts = append(opts, oapi.WithComposeEmbedded(true))
+	}
+
+	if cfg.ProtoJSON {
+		opts = append(opts, oapi.WithProtoJSON(true))
+	}
+
+	if cfg.AutoExport {
+		opts = append(opts, oapi.WithAutoExport(true))
+	}
+
+	if cfg.SplitReadWrite {
+		opts = append(opts, oapi.WithSplitReadWrite(true))
+	}
+
+	opts = append(opts, oapi.WithNaming(cfg.Naming))
+
+	opts = append(opts, oapi.WithRemote(cfg.Remote))
+
+	if len(cfg.OpenAPI) > 0 {
+		opts = append(opts, oapi.WithOpenAPI(cfg.OpenAPI))
+	}
+
 	if len(cfg.Operations) > 0 {
 		opts = append(opts, oapi.WithDefOps(cfg.Operations))
 	}
@@ -31,6 +67,7 @@ func scan(ctx context.Context, log logging.Printer, cfg config.Config) (oapi.OAP
 	opts = append(opts, oapi.WithRootSchema(spec.OpenAPI{
 		Info:         cfg.Info,
 		Servers:      cfg.Servers,
+		Webhooks:     cfg.Webhooks,
 		Components:   cfg.Components,
 		Security:     cfg.Security,
 		Tags:         cfg.Tags,
@@ -60,6 +60,148 @@
 //  //oapi:schema Object
 //  //oapi:schema go://github.com/buypal/oapi-go#/Object
 //
+// Instantiated generic types are referred to with their type arguments,
+// arguments declared in other packages are qualified by package name:
+//
+//  go://github.com/buypal/oapi-go#/Page[Item]
+//  go://github.com/buypal/oapi-go#/Page[items.Item]
+//
+// Exported instances are named after type and its arguments,
+// //oapi:schema Page[Item] is exported as PageItem.
+//
+// With WithAutoExport named structs which are referenced more than once or
+// which are recursive are exported as components under name of their type,
+// otherwise they are inlined and recursive references become empty objects.
+//
+// Components without explicit name are named by WithNaming strategy: bare
+// type names (Item), package prefixed (itemsItem), qualified (items.Item)
+// or text/template with .Name, .Package and .PkgPath. Names colliding across
+// packages are errors unless collisions are resolved, then they are prefixed
+// by as many elements of package path as needed (v1ItemsItem).
+//
+// Common types of standard library (time.Time, time.Duration, net.IP,
+// url.URL, ...) and github.com/google/uuid.UUID have built-in schemas, byte
+// slices are described as base64 strings. Schemas of other types can be
+// registered by their go type name with WithTypes option or in config:
+//
+//  types:
+//    github.com/shopspring/decimal.Decimal:
+//      type: string
+//      format: decimal
+//
+// Types implementing encoding.TextMarshaler are described as strings.
+// Types implementing json.Marshaler can produce anything, therefore
+// their schema has to be provided either by override or by command:
+//
+//  //openapi:type <name or source of type> <type> [format]
+//
+// Examples:
+//  //openapi:type Money string decimal
+//  //openapi:type go://github.com/buypal/oapi-go#/Money number
+//
+// Options of oapi tag take value either after colon or after equal sign,
+// so oapi:"required:false" and oapi:"required=false" are the same.
+//
+// Fields with ",string" option of json tag are described as strings same way
+// encoding/json sends them, numbers get pattern matching their wire format.
+// Option can be set also by oapi tag for types with custom marshalers:
+//  ID int64 `oapi:"id,string"`
+//
+// Oapi tag can provide example, default, enum, title and description of
+// field. Values are parsed according to type of field, objects and arrays
+// are written as json, values containing comma are quoted by single quotes:
+//  Size int    `oapi:"size,default:10,example:25"`
+//  Kind string `oapi:"kind,enum:a|b|c,description:'Kind of item, a by default'"`
+//
+// Specification extensions are set by x-* options of oapi tag, values which
+// are not valid json are strings. Extensions of type are set by command:
+//  Name string `oapi:"x-go-name=ItemName,x-internal:true"`
+//
+//  //openapi:extension <name or source of type> <x-key=value> [...]
+//
+// Example:
+//  //openapi:extension Item x-internal=true x-go-name=Item
+//
+// Xml tags describe xml representation of schemas. Name of element and its
+// namespace is taken from XMLName field, attributes and namespaces of fields
+// are kept, a>b chains describe wrapped arrays and character data is marked
+// by x-text extension, as OpenAPI has no way to describe text nodes. In
+// structs having xml tags elements not named by tag are named by go field
+// as encoding/xml does it. Longer chains and chains of other fields than
+// arrays are reported as errors.
+//
+// Keys of maps are described as encoding/json encodes them. Integer keys are
+// restricted by propertyNames pattern, keys of enum types become closed set
+// of properties and keys which can not be encoded are reported as errors.
+// OpenAPI 3.0 does not allow propertyNames, so they are kept in
+// x-propertyNames extension there and become propertyNames for OpenAPI 3.1.
+//
+// Fields of embedded structs are inlined into parent. Embedded types can be
+// composed instead, parent is then described as allOf embedded type and its
+// own properties. Composition is enabled by WithComposeEmbedded for embedded
+// types exported as schemas, or per field by tag:
+//  type AdminUser struct {
+//      User `oapi:",compose"`
+//      Perms []string
+//  }
+//
+// Types generated by protoc-gen-go are described as protojson encodes them
+// when WithProtoJSON is enabled. Names are taken from protobuf tags, 64 bit
+// integers are strings, enums are described by their names, oneof fields
+// become oneOf and well known types such as timestamppb.Timestamp or
+// wrapperspb.Int64Value are described by their canonical json form.
+//
+// Interfaces are supported only if they are declared as polymorphic,
+// then every field of such interface is described as oneOf listed types:
+//
+//  //openapi:oneof <interface> [type[=value] ...] [discriminator=<property>]
+//
+// Examples:
+//  //openapi:oneof Shape Circle Square=square discriminator=kind
+//  //openapi:oneof Shape discriminator=kind
+//
+// Listed types are exported as schemas, value is what discriminator property
+// holds for given type (type name by default). If no types are listed,
+// implementations of interface are searched for in scanned packages.
+//
+// Operations can be declared on handler funcs instead of oapi.yaml, they
+// are merged with specs of packages and can not collide with them:
+//
+//  //openapi:operation <method> <path> [tags=<tag,...>] [operationId=<id>]
+//  //    [request=<source>] response <code> [source] [response <code> [source] ...]
+//
+// Example:
+//  //openapi:operation GET /v1/items/{id} tags=items response 200 Item response 404
+//  func GetItem(w http.ResponseWriter, r *http.Request) {}
+//
+// Operation id defaults to name of func, path parameters are described as
+// strings and bodies as json, responses are described by status text.
+// At least one response is required, paths can contain dots (ie. .json).
+//
+// Schema of type can be exported as component of other kind than schema,
+// references to its pointer then refer to that component:
+//  //openapi:response [name] <source>
+//  //openapi:requestBody [name] <source>
+//  //openapi:header [name] <source>
+//  //openapi:parameter [name] <source> in=<query|header|path|cookie> [name=<param>]
+//
+// Example:
+//  //openapi:response NotFound go://#/ErrorBody
+//
+// Reference go://#/ErrorBody then becomes #/components/responses/NotFound.
+// Bodies are json, responses are described by description of schema.
+// Type can be exported only once, so such type should not be referenced
+// from schemas.
+//
+// Schemas having readOnly or writeOnly properties can be split by
+// WithSplitReadWrite into <Name>Input and <Name>Output components, request
+// bodies then refer to input variants and responses to output variants.
+//
+// Version of produced document is set by WithOpenAPI (openapi in config or
+// --openapi flag). For 3.1 schemas are converted to JSON Schema 2020-12,
+// nullable becomes null type, exclusive bounds become numbers and examples
+// replace example. Webhooks, $defs and const are supported for 3.1.
+//
 // Merging specifications
 //
 // One of the goals of this package was also to provide way how to merge multiple
@@ -68,6 +210,34 @@
 // by running oapi command.
 // This allows to mantain per package openapi specifications.
 //
+// References can point to yaml or json files outside of go packages,
+// either by relative path or by file uri:
+//  {"$ref": "./schemas/error.yaml#/Error"}
+//  {"$ref": "file:///abs/path/schemas.json#/Error"}
+// Relative paths are resolved against file containing reference (package
+// directory for commands). Referenced fragment is inlined unless it is
+// exported, ie. by //openapi:schema Error ./schemas/error.yaml#/Error.
+//
+// Documents referenced by http(s) uri are fetched and, when WithRemote sets
+// cache directory, stored on disk so they can be served offline (--offline
+// flag). Lock file pins content of documents by sha256, documents whose
+// content changed are rejected.
+//
+// Other schemes can be resolved by WithResolver, ie. db://users#/User.
+// Resolver returns entity of package spec, references within it are
+// resolved as well:
+//  oapi.WithResolver("db", func(ctx context.Context, p pointer.Pointer) (spec.Entiter, error) {
+//  	return spec.Schema{Type: "object"}, nil
+//  })
+//
+// Errors
+//
+// Errors of scan are reported as Error holding position in go file (types,
+// commands) or in spec file (references, merging) and chain of pointers
+// which were resolved when error occurred. Errors of all scanned packages
+// are aggregated into ErrorList:
+//  items/oapi.yaml:21: failed to resolve "go://items#/Missing" (via go://items#/Missing)
+//
 // Additional RFC documents
 //
 // https://tools.ietf.org/html/rfc3986
@@ -2,12 +2,14 @@ package container
 
 import (
 	"encoding/json"
+	"go/token"
 	"io/ioutil"
 	"path/filepath"
 	"reflect"
 	"sort"
 
 	"github.com/Jeffail/gabs/v2"
+	"github.com/buypal/oapi-go/internal/diag"
 	"github.com/buypal/oapi-go/internal/logging"
 	"github.com/pkg/errors"
 	"gopkg.in/yaml.v2"
@@ -84,6 +86,7 @@ func ReadFile(file string) (c Container, err error) {
 		err = errors.Errorf("failed to recognize extension %q for unmarshal", ext)
 	}
 	if err != nil {
+		err = diag.At(token.Position{Filename: file}, err)
 		return
 	}
 	c.path = file
@@ -1,13 +1,19 @@
 package container
 
-import "github.com/pkg/errors"
+import (
+	"go/token"
+	"strings"
+
+	"github.com/buypal/oapi-go/internal/diag"
+	"github.com/pkg/errors"
+)
 
 // Merger is function signature allowing merging
 type Merger func(destination, source interface{}) (interface{}, error)
 
 // MergeStrict will merge strictly, no collisions
 func MergeStrict(dest, source interface{}) (interface{}, error) {
-	return nil, errors.Errorf("%v collied with %v", dest, source)
+	return nil, errors.Errorf("%v collides with %v", dest, source)
 }
 
 // MergeDefault will merge default
@@ -27,6 +33,7 @@ func MergeOverride(dest, source interface{}) (interface{}, error) {
 // Which ever value is returned becomes the new value in the destination object
 // at the location of the collision.
 func (c Container) Merge(source Container, collisionFn Merger) error {
+	file := source.path
 	source = source.Clone() // make sure we are not moving pointers
 
 	var recursiveFnc func(map[string]interface{}, []string) error
@@ -54,7 +61,7 @@ func (c Container) Merge(source Container, collisionFn Merger) error {
 				default:
 					xx, err := collisionFn(existingVal, t)
 					if err != nil {
-						return errors.Wrapf(err, "at %s", source.path)
+						return collision(file, newPath, err)
 					}
 					_, err = c.c.Set(xx, newPath...)
 					if err != nil {
@@ -64,7 +71,7 @@ func (c Container) Merge(source Container, collisionFn Merger) error {
 			default:
 				xx, err := collisionFn(existingData, t)
 				if err != nil {
-					return errors.Wrapf(err, "at %s", source.path)
+					return collision(file, newPath, err)
 				}
 				_, err = c.c.Set(xx, newPath...)
 				if err != nil {
@@ -79,3 +86,12 @@ func (c Container) Merge(source Container, collisionFn Merger) error {
 	}
 	return nil
 }
+
+// collision reports collision at path of file being merged
+func collision(file string, path []string, err error) error {
+	err = errors.Wrapf(err, "at %s", strings.Join(path, "."))
+	if len(file) == 0 {
+		return err
+	}
+	return diag.At(token.Position{Filename: file}, err)
+}
@@ -3,43 +3,54 @@ package container
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/Jeffail/gabs/v2"
 	"github.com/pkg/errors"
 )
 
-// ExtractKey will extract keys from container
+// ExtractKey will extract keys from container, keys of
+// returned items are dot paths with escaped segments.
 func ExtractKey(c Container, key string) (pp MapSlice, err error) {
-	ff, err := c.Flatten()
-	if err != nil {
-		return nil, err
+	switch c.Data().(type) {
+	case map[string]interface{}, []interface{}:
+	default:
+		return nil, gabs.ErrNotObjOrArray
 	}
-	var ii int
-	for k, v := range ff {
-		x := strings.Split(k, ".")
-		if len(x) == 0 {
-			continue
+	extractKey(c.Data(), nil, key, &pp)
+	return pp, nil
+}
+
+func extractKey(v interface{}, path []string, key string, pp *MapSlice) {
+	switch x := v.(type) {
+	case map[string]interface{}:
+		for k, y := range x {
+			extractKey(y, append(path[:len(path):len(path)], k), key, pp)
 		}
-		if x[len(x)-1] != key {
-			continue
+	case []interface{}:
+		for i, y := range x {
+			extractKey(y, append(path[:len(path):len(path)], strconv.Itoa(i)), key, pp)
+		}
+	default:
+		if len(path) == 0 || path[len(path)-1] != key {
+			return
 		}
-		pp = append(pp, MapItem{
-			Key:   k,
+		*pp = append(*pp, MapItem{
+			Key:   SliceToDotPath(path),
 			Val:   v,
-			Index: ii,
+			Index: len(*pp),
 		})
-		ii++
 	}
-	return pp, nil
 }
 
-// SliceToDotPath converts slice into dot path
+// SliceToDotPath converts slice into dot path, '~' and '.'
+// in segments are escaped as '~0' and '~1'.
 func SliceToDotPath(path []string) string {
 	hierarchy := make([]string, len(path))
 	for i, v := range path {
-		v = strings.Replace(v, ".", "~1", -1)
 		v = strings.Replace(v, "~", "~0", -1)
+		v = strings.Replace(v, ".", "~1", -1)
 		hierarchy[i] = v
 	}
 	return strings.Join(hierarchy, ".")
@@ -4,6 +4,7 @@ import (
 	"sort"
 	"testing"
 
+	"github.com/Jeffail/gabs/v2"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v2"
 )
@@ -33,6 +34,27 @@ func TestExtractKey(t *testing.T) {
 	require.Len(t, ss, 2)
 }
 
+func TestExtractKeyEscaped(t *testing.T) {
+	c, err := ReadYAML([]byte(`
+paths:
+  /v1/files/{name}.json:
+    get:
+      $ref: a
+  /v1~2:
+    $ref: b
+`))
+	require.NoError(t, err)
+
+	ss, err := ExtractKey(c, "$ref")
+	require.NoError(t, err)
+	require.Len(t, ss, 2)
+
+	for _, s := range ss {
+		require.Equal(t, s.Val, c.Path(s.Key).Data())
+	}
+	require.Equal(t, []string{"paths", "/v1/files/{name}.json", "get", "$ref"}, gabs.DotPathToSlice(SliceToDotPath([]string{"paths", "/v1/files/{name}.json", "get", "$ref"})))
+}
+
 func TestMmap(t *testing.T) {
 	y := "a:\n  1: 1\n  2: 2\n"
 
@@ -0,0 +1,119 @@
+// Package diag provides errors carrying position in go or spec file
+// and chain of pointers which led to them, so failures of large
+// specifications can be traced back to their source.
+package diag
+
+import (
+	"go/token"
+	"strings"
+
+	"github.com/buypal/oapi-go/pointer"
+	"github.com/pkg/errors"
+)
+
+// Error is error with position in source file, either go file (types,
+// commands) or spec file (references, merging) and chain of pointers
+// being resolved, outermost first.
+type Error struct {
+	Pos   token.Position
+	Chain []pointer.Pointer
+	Err   error
+}
+
+// Error returns error prefixed by position and followed by chain of pointers
+func (e *Error) Error() string {
+	var b strings.Builder
+	if hasPos(e.Pos) {
+		b.WriteString(e.Pos.String())
+		b.WriteString(": ")
+	}
+	b.WriteString(e.Err.Error())
+	if len(e.Chain) > 0 {
+		ss := make([]string, len(e.Chain))
+		for i, p := range e.Chain {
+			ss[i] = p.String()
+		}
+		b.WriteString(" (via ")
+		b.WriteString(strings.Join(ss, " -> "))
+		b.WriteString(")")
+	}
+	return b.String()
+}
+
+// Unwrap returns underlying error
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Cause returns underlying error, see github.com/pkg/errors
+func (e *Error) Cause() error {
+	return e.Err
+}
+
+// At sets position of error, error which already has position keeps it,
+// as innermost position is the most accurate one.
+func At(pos token.Position, err error) error {
+	if err == nil {
+		return nil
+	}
+	var e *Error
+	if !errors.As(err, &e) {
+		return &Error{Pos: pos, Err: err}
+	}
+	if !hasPos(e.Pos) {
+		e.Pos = pos
+	}
+	return err
+}
+
+// Via prepends pointer to chain of pointers of error.
+func Via(p pointer.Pointer, err error) error {
+	if err == nil {
+		return nil
+	}
+	var e *Error
+	if !errors.As(err, &e) {
+		return &Error{Chain: []pointer.Pointer{p}, Err: err}
+	}
+	e.Chain = append([]pointer.Pointer{p}, e.Chain...)
+	return err
+}
+
+// PosOf returns position of error if it has one
+func PosOf(err error) (token.Position, bool) {
+	var e *Error
+	if !errors.As(err, &e) || !hasPos(e.Pos) {
+		return token.Position{}, false
+	}
+	return e.Pos, true
+}
+
+// ChainOf returns chain of pointers of error
+func ChainOf(err error) []pointer.Pointer {
+	var e *Error
+	if !errors.As(err, &e) {
+		return nil
+	}
+	return e.Chain
+}
+
+func hasPos(pos token.Position) bool {
+	return len(pos.Filename) > 0 || pos.IsValid()
+}
+
+// List aggregates errors, ie. errors of all scanned packages.
+type List []error
+
+// Error lists all errors
+func (l List) Error() string {
+	msgs := make([]string, len(l))
+	for i, err := range l {
+		msgs[i] = "\t- " + err.Error()
+	}
+	return "failed to scan: \n" + strings.Join(msgs, "\n")
+}
+
+// Unwrap returns aggregated errors
+func (l List) Unwrap() []error {
+	return l
+}
@@ -0,0 +1,48 @@
+package diag
+
+import (
+	"go/token"
+	"testing"
+
+	"github.com/buypal/oapi-go/pointer"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestError(t *testing.T) {
+	inner := token.Position{Filename: "items/item.go", Line: 12, Column: 2}
+	outer := token.Position{Filename: "items/oapi.yaml", Line: 7}
+
+	err := At(inner, errors.New(`field "Price": unsupported type`))
+	err = errors.Wrap(err, `type "go://items#/Item"`)
+	err = Via(pointer.MustParse("go://items#/Item"), err)
+	err = Via(pointer.MustParse("go://items#/List"), err)
+	err = At(outer, err)
+
+	// innermost position is kept
+	pos, ok := PosOf(err)
+	require.True(t, ok)
+	require.Equal(t, inner, pos)
+	require.Equal(t, []pointer.Pointer{
+		pointer.MustParse("go://items#/List"),
+		pointer.MustParse("go://items#/Item"),
+	}, ChainOf(err))
+	require.Equal(t, `type "go://items#/Item": items/item.go:12:2: field "Price": unsupported type (via go://items#/List -> go://items#/Item)`, err.Error())
+
+	err = At(outer, errors.New("failed to parse pointer"))
+	require.Equal(t, "items/oapi.yaml:7: failed to parse pointer", err.Error())
+
+	_, ok = PosOf(errors.New("plain"))
+	require.False(t, ok)
+}
+
+func TestList(t *testing.T) {
+	a := At(token.Position{Filename: "a.go", Line: 1, Column: 1}, errors.New("a"))
+	b := errors.New("b")
+	l := List{a, b}
+	require.Equal(t, "failed to scan: \n\t- a.go:1:1: a\n\t- b", l.Error())
+
+	var e *Error
+	require.True(t, errors.As(error(l), &e))
+	require.Equal(t, "a.go", e.Pos.Filename)
+}
@@ -5,7 +5,8 @@ import (
 	"path/filepath"
 
 	"github.com/buypal/oapi-go/internal/container"
-	"github.com/buypal/oapi-go/internal/oapi/spec"
+	"github.com/buypal/oapi-go/internal/oapi/resolver"
+	"github.com/buypal/oapi-go/spec"
 )
 
 type Config struct {
@@ -18,7 +19,7 @@ type Config struct {
 	// what version of config for oapi go should be used
 	Version string `json:"version"`
 
-	// what openapi version to produce
+	// what openapi version to produce, ie. 3.0.3 or 3.1.0
 	OpenAPI string `json:"openapi"`
 
 	// Format to produce
@@ -36,6 +37,33 @@ type Config struct {
 	// go pkgs to exclude from scan
 	Exclude []string `json:"exclude"`
 
+	// integer enums with String() method are documented using
+	// values of String() instead of integers
+	EnumStringer bool `json:"enumStringer"`
+
+	// fields which are neither omitempty nor pointers are required
+	InferRequired bool `json:"inferRequired"`
+
+	// structs embedding exported types are described by allOf
+	ComposeEmbedded bool `json:"composeEmbedded"`
+
+	// protoc-gen-go types are described as encoded by protojson
+	ProtoJSON bool `json:"protoJSON"`
+
+	// schemas with readOnly or writeOnly properties are split
+	// into Input and Output variants
+	SplitReadWrite bool `json:"splitReadWrite"`
+
+	// named structs referenced more than once or recursive
+	// are exported as components
+	AutoExport bool `json:"autoExport"`
+
+	// resolving of http(s) references
+	Remote resolver.HTTPOptions `json:"remote"`
+
+	// how exported components are named
+	Naming resolver.Naming `json:"naming"`
+
 	// Provides metadata about the API.
 	// The metadata MAY be used by tooling as required.
 	Info *spec.Info `json:"info"`
@@ -44,6 +72,10 @@ type Config struct {
 	// If the servers property is not provided, or is an empty array, the default value would be a Server Object with a url value of /.
 	Servers []*spec.Server `json:"servers"`
 
+	// The incoming webhooks that MAY be received as part of this API.
+	// Supported since openapi 3.1.
+	Webhooks map[string]*spec.PathItem `json:"webhooks"`
+
 	// A declaration of which security mechanisms can be used across the API.
 	// The list of values includes alternative security requirement objects that can be used.
 	// Only one of the security requirement objects need to be satisfied to authorize a request.
@@ -66,6 +98,9 @@ type Config struct {
 	// types to override, key is pointer
 	Overrides map[string]spec.Schema `json:"overrides"`
 
+	// schemas of go types, key is go type name ie. github.com/google/uuid.UUID
+	Types map[string]spec.Schema `json:"types"`
+
 	// Operations are defaults for operations
 	Operations map[string]spec.Operation `json:"operations"`
 }
@@ -2,8 +2,8 @@ package oapi
 
 import (
 	"github.com/buypal/oapi-go/internal/container"
-	"github.com/buypal/oapi-go/internal/oapi/spec"
 	"github.com/buypal/oapi-go/internal/route"
+	"github.com/buypal/oapi-go/spec"
 )
 
 // FlattenPath represents single reuqest path in oapi spec.
@@ -115,6 +115,7 @@ func MergeWithRoot(root spec.OpenAPI, c container.Container) (err error) {
 		{merge: container.MergeOverride, key: "info"},
 		{merge: container.MergeStrict, key: "components"},
 		{merge: container.MergeDefault, key: "paths"},
+		{merge: container.MergeDefault, key: "webhooks"},
 		{merge: container.MergeDefault, key: "externalDocs"},
 		{merge: container.MergeDefault, key: "security"},
 		{merge: container.MergeDefault, key: "servers"},
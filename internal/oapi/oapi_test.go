@@ -4,7 +4,7 @@ import (
 	"testing"
 
 	"github.com/buypal/oapi-go/internal/container"
-	"github.com/buypal/oapi-go/internal/oapi/spec"
+	"github.com/buypal/oapi-go/spec"
 	"github.com/stretchr/testify/require"
 )
 
@@ -41,3 +41,294 @@ func TestDefaults(t *testing.T) {
       summary: Summary
 `)
 }
+
+func TestSplitReadWrite(t *testing.T) {
+	cnt, err := container.ReadYAML([]byte(`
+paths:
+  /users:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: "#/components/schemas/Users"
+      responses:
+        "200":
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/Users"
+components:
+  schemas:
+    Users:
+      type: array
+      items:
+        $ref: "#/components/schemas/User"
+    User:
+      type: object
+      required:
+      - id
+      - password
+      - name
+      properties:
+        id:
+          type: string
+          readOnly: true
+        password:
+          type: string
+          writeOnly: true
+        name:
+          type: string
+    Plain:
+      type: string
+`))
+	require.NoError(t, err)
+
+	err = SplitReadWrite(cnt)
+	require.NoError(t, err)
+
+	op := cnt.Path("paths./users.post")
+	require.Equal(t, "#/components/schemas/UsersInput", op.Path("requestBody.content.application/json.schema.$ref").Data())
+	require.Equal(t, "#/components/schemas/UsersOutput", op.Path("responses.200.content.application/json.schema.$ref").Data())
+
+	data, err := cnt.Path("components.schemas").MarshalYAML()
+	require.NoError(t, err)
+	require.Equal(t, `Plain:
+  type: string
+User:
+  properties:
+    id:
+      readOnly: true
+      type: string
+    name:
+      type: string
+    password:
+      type: string
+      writeOnly: true
+  required:
+  - id
+  - password
+  - name
+  type: object
+UserInput:
+  properties:
+    name:
+      type: string
+    password:
+      type: string
+      writeOnly: true
+  required:
+  - password
+  - name
+  type: object
+UserOutput:
+  properties:
+    id:
+      readOnly: true
+      type: string
+    name:
+      type: string
+  required:
+  - id
+  - name
+  type: object
+Users:
+  items:
+    $ref: '#/components/schemas/User'
+  type: array
+UsersInput:
+  items:
+    $ref: '#/components/schemas/UserInput'
+  type: array
+UsersOutput:
+  items:
+    $ref: '#/components/schemas/UserOutput'
+  type: array
+`, string(data))
+}
+
+func TestSetVersion(t *testing.T) {
+	doc := `
+openapi: 3.0.3
+paths:
+  /items:
+    get:
+      parameters:
+      - name: limit
+        in: query
+        schema:
+          type: integer
+          minimum: 0
+          exclusiveMinimum: true
+          example: 10
+      responses:
+        "200":
+          description: Items
+          headers:
+            X-Next:
+              schema:
+                type: string
+                nullable: true
+          content:
+            application/json:
+              example:
+                schema:
+                  nullable: true
+              x-payload:
+                schema:
+                  nullable: true
+components:
+  schemas:
+    Item:
+      type: object
+      properties:
+        kind:
+          type: string
+          nullable: true
+          enum:
+          - a
+          - b
+        parent:
+          $ref: "#/components/schemas/Item"
+          nullable: true
+        price:
+          type: number
+          maximum: 100
+          exclusiveMaximum: false
+`
+	cnt, err := container.ReadYAML([]byte(doc))
+	require.NoError(t, err)
+
+	err = SetVersion(cnt, "3.1.0")
+	require.NoError(t, err)
+
+	data, err := cnt.MarshalYAML()
+	require.NoError(t, err)
+	require.Equal(t, `components:
+  schemas:
+    Item:
+      properties:
+        kind:
+          enum:
+          - a
+          - b
+          - null
+          type:
+          - string
+          - "null"
+        parent:
+          anyOf:
+          - $ref: '#/components/schemas/Item'
+          - type: "null"
+        price:
+          maximum: 100
+          type: number
+      type: object
+openapi: 3.1.0
+paths:
+  /items:
+    get:
+      parameters:
+      - in: query
+        name: limit
+        schema:
+          examples:
+          - 10
+          exclusiveMinimum: 0
+          type: integer
+      responses:
+        "200":
+          content:
+            application/json:
+              example:
+                schema:
+                  nullable: true
+              x-payload:
+                schema:
+                  nullable: true
+          description: Items
+          headers:
+            X-Next:
+              schema:
+                type:
+                - string
+                - "null"
+`, string(data))
+
+	// null types and numeric bounds are converted back
+	err = SetVersion(cnt, "3.0.3")
+	require.NoError(t, err)
+	data, err = cnt.MarshalYAML()
+	require.NoError(t, err)
+	require.Contains(t, string(data), `        kind:
+          enum:
+          - a
+          - b
+          - null
+          nullable: true
+          type: string
+        parent:
+          $ref: '#/components/schemas/Item'
+          nullable: true
+`)
+	require.Equal(t, true, cnt.Path("paths./items.get.responses.200.headers.X-Next.schema.nullable").Data())
+	limit := "paths./items.get.parameters.0.schema"
+	require.EqualValues(t, 0, cnt.Path(limit+".minimum").Data())
+	require.Equal(t, true, cnt.Path(limit+".exclusiveMinimum").Data())
+
+	cnt, err = container.ReadYAML([]byte(`
+components:
+  schemas:
+    ID:
+      type: [string, integer]
+`))
+	require.NoError(t, err)
+	require.Error(t, SetVersion(cnt, "3.0.3"))
+
+	cnt, err = container.ReadYAML([]byte(`
+components:
+  schemas:
+    Kind:
+      const: a
+      examples:
+      - a
+`))
+	require.NoError(t, err)
+
+	err = SetVersion(cnt, "3.0.3")
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{"a"}, cnt.Path("components.schemas.Kind.enum").Data())
+	require.Equal(t, "a", cnt.Path("components.schemas.Kind.example").Data())
+
+	// names of properties are extension in 3.0
+	cnt, err = container.ReadYAML([]byte(`
+components:
+  schemas:
+    Counts:
+      type: object
+      additionalProperties:
+        type: integer
+      x-propertyNames:
+        type: string
+        pattern: ^-?[0-9]+$
+`))
+	require.NoError(t, err)
+
+	err = SetVersion(cnt, "3.1.0")
+	require.NoError(t, err)
+	require.Nil(t, cnt.Path("components.schemas.Counts.x-propertyNames").Data())
+	require.Equal(t, "^-?[0-9]+$", cnt.Path("components.schemas.Counts.propertyNames.pattern").Data())
+
+	err = SetVersion(cnt, "3.0.3")
+	require.NoError(t, err)
+	require.Nil(t, cnt.Path("components.schemas.Counts.propertyNames").Data())
+	require.Equal(t, "^-?[0-9]+$", cnt.Path("components.schemas.Counts.x-propertyNames.pattern").Data())
+
+	cnt, err = container.ReadYAML([]byte(`
+webhooks:
+  created: {}
+`))
+	require.NoError(t, err)
+	require.Error(t, SetVersion(cnt, "3.0.3"))
+	require.Error(t, SetVersion(cnt, "2.0"))
+}
@@ -0,0 +1,88 @@
+package resolver
+
+import (
+	"path/filepath"
+
+	"github.com/buypal/oapi-go/internal/container"
+	"github.com/buypal/oapi-go/pointer"
+	"github.com/buypal/oapi-go/spec"
+	"github.com/pkg/errors"
+)
+
+// Raw is part of document of unknown kind, ie. fragment of file.
+type Raw struct {
+	container.Container
+}
+
+// Entity satisfies componenter interface
+func (Raw) Entity() spec.Entity {
+	return spec.ReferenceKind
+}
+
+// FileFn returns Fn resolving pointers of file scheme (file:///path.yaml#/x).
+// Files are read by container.ReadFile and fragment of pointer is looked up
+// in it. References in file relative to it are made absolute, so they can
+// be resolved later on. Files are read only once, each fragment is copy
+// so changes made by resolver do not leak into other references.
+func FileFn() Fn {
+	files := make(map[string]container.Container)
+	return func(p pointer.Pointer) (spec.Entiter, error) {
+		if p.Scheme != "file" {
+			return nil, errors.Errorf("pointer %q does not refer to file", p.String())
+		}
+		path := filepath.FromSlash(p.Path)
+		c, ok := files[path]
+		if !ok {
+			var err error
+			c, err = readFile(path)
+			if err != nil {
+				return nil, err
+			}
+			files[path] = c
+		}
+		x := c
+		if !p.Fragment.IsEmpty() {
+			x = c.Path(container.SliceToDotPath(p.Fragment))
+		}
+		if x.Data() == nil {
+			return nil, errors.Errorf("failed to resolve %q, fragment not found", p.String())
+		}
+		return Raw{Container: x.Clone()}, nil
+	}
+}
+
+// readFile reads file and makes its references absolute, local references
+// (#/components/...) refer to same file.
+func readFile(path string) (c container.Container, err error) {
+	c, err = container.ReadFile(path)
+	if err != nil {
+		return
+	}
+	refs, err := container.ExtractKey(c, "$ref")
+	if err != nil {
+		return
+	}
+	for _, v := range refs {
+		s, ok := v.Val.(string)
+		if !ok {
+			continue
+		}
+		var p pointer.Pointer
+		p, err = pointer.Parse(s)
+		if err != nil {
+			return
+		}
+		if p.IsExternal() {
+			continue
+		}
+		if !p.IsRelative() {
+			p.Path = path
+		}
+		p = p.InDir(filepath.Dir(path))
+		err = c.SetP(v.Key, p.String())
+		if err != nil {
+			return
+		}
+	}
+	return
+}
@@ -0,0 +1,241 @@
+package resolver
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/buypal/oapi-go/internal/container"
+	"github.com/buypal/oapi-go/pointer"
+	"github.com/buypal/oapi-go/spec"
+	"github.com/pkg/errors"
+)
+
+// HTTPOptions configures resolving of http(s) pointers.
+type HTTPOptions struct {
+	// CacheDir is directory where fetched documents are stored,
+	// if empty documents are not stored.
+	CacheDir string `json:"cacheDir"`
+
+	// Offline will serve documents only from cache.
+	Offline bool `json:"offline"`
+
+	// LockFile pins content of fetched documents by their sha256 hash,
+	// documents which content changed are rejected.
+	LockFile string `json:"lockFile"`
+
+	// Client used to fetch documents, by default client
+	// with DefaultTimeout is used.
+	Client *http.Client `json:"-"`
+}
+
+// DefaultTimeout is timeout of fetching single document
+// if client is not set.
+const DefaultTimeout = 30 * time.Second
+
+// lock maps urls of documents to hashes of their content
+type lock map[string]string
+
+// HTTPFn returns Fn resolving pointers of http and https scheme. Documents
+// are fetched once and stored in cache directory, so they can be served
+// offline. References relative to document are made absolute. Fetching
+// is canceled with context, fragments are copies of cached documents.
+func HTTPFn(ctx context.Context, o HTTPOptions) Fn {
+	if o.Client == nil {
+		o.Client = &http.Client{Timeout: DefaultTimeout}
+	}
+	docs := make(map[string]container.Container)
+	var locked lock
+
+	return func(p pointer.Pointer) (spec.Entiter, error) {
+		if p.Scheme != "http" && p.Scheme != "https" {
+			return nil, errors.Errorf("pointer %q does not refer to http document", p.String())
+		}
+		if locked == nil {
+			var err error
+			locked, err = readLock(o.LockFile)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		u := p.URL
+		u.Fragment = ""
+		c, ok := docs[u.String()]
+		if !ok {
+			data, err := o.fetch(ctx, u, locked)
+			if err != nil {
+				return nil, err
+			}
+			c, err = readRemote(u, data)
+			if err != nil {
+				return nil, err
+			}
+			docs[u.String()] = c
+		}
+
+		x := c
+		if !p.Fragment.IsEmpty() {
+			x = c.Path(container.SliceToDotPath(p.Fragment))
+		}
+		if x.Data() == nil {
+			return nil, errors.Errorf("failed to resolve %q, fragment not found", p.String())
+		}
+		return Raw{Container: x.Clone()}, nil
+	}
+}
+
+// fetch returns content of document either from server or from cache
+// if offline, content is checked against lock file.
+func (o HTTPOptions) fetch(ctx context.Context, u url.URL, locked lock) (data []byte, err error) {
+	cached := o.cachePath(u)
+
+	if o.Offline {
+		if len(cached) == 0 {
+			return nil, errors.Errorf("failed to fetch %q, no cache directory for offline mode", u.String())
+		}
+		data, err = os.ReadFile(cached)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to fetch %q offline", u.String())
+		}
+		return data, o.verify(u, data, locked)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to fetch %q", u.String())
+	}
+	resp, err := o.Client.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to fetch %q", u.String())
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("failed to fetch %q, status %s", u.String(), resp.Status)
+	}
+	data, err = io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to fetch %q", u.String())
+	}
+
+	err = o.verify(u, data, locked)
+	if err != nil {
+		return
+	}
+
+	if len(cached) == 0 {
+		return
+	}
+	err = os.MkdirAll(o.CacheDir, 0755)
+	if err != nil {
+		return
+	}
+	err = os.WriteFile(cached, data, 0644)
+	return
+}
+
+// verify checks hash of content against lock file, unknown
+// documents are added to lock file.
+func (o HTTPOptions) verify(u url.URL, data []byte, locked lock) error {
+	if len(o.LockFile) == 0 {
+		return nil
+	}
+	sum := sha256.Sum256(data)
+	hash := "sha256:" + hex.EncodeToString(sum[:])
+	expected, ok := locked[u.String()]
+	if ok && expected != hash {
+		return errors.Errorf("content of %q does not match lock file, expected %s got %s", u.String(), expected, hash)
+	}
+	if ok {
+		return nil
+	}
+	if o.Offline {
+		return errors.Errorf("document %q is not locked", u.String())
+	}
+	locked[u.String()] = hash
+	return writeLock(o.LockFile, locked)
+}
+
+// cachePath returns path of cached document, it is named
+// by hash of url keeping extension of document.
+func (o HTTPOptions) cachePath(u url.URL) string {
+	if len(o.CacheDir) == 0 {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(u.String()))
+	return filepath.Join(o.CacheDir, hex.EncodeToString(sum[:])+filepath.Ext(u.Path))
+}
+
+// readRemote parses document and makes its references absolute,
+// local references (#/components/...) refer to same document.
+func readRemote(u url.URL, data []byte) (c container.Container, err error) {
+	if filepath.Ext(u.Path) == ".json" {
+		c, err = container.ReadJSON(data)
+	} else {
+		c, err = container.ReadYAML(data)
+	}
+	if err != nil {
+		return c, errors.Wrapf(err, "failed to read %q", u.String())
+	}
+	refs, err := container.ExtractKey(c, "$ref")
+	if err != nil {
+		return
+	}
+	for _, v := range refs {
+		s, ok := v.Val.(string)
+		if !ok {
+			continue
+		}
+		var p pointer.Pointer
+		p, err = pointer.Parse(s)
+		if err != nil {
+			return
+		}
+		if p.IsExternal() {
+			continue
+		}
+		ref := u.ResolveReference(&p.URL)
+		ref.Fragment = p.Fragment.String()
+		err = c.SetP(v.Key, ref.String())
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// readLock reads lock file, missing file is empty lock
+func readLock(file string) (lock, error) {
+	l := make(lock)
+	if len(file) == 0 {
+		return l, nil
+	}
+	data, err := os.ReadFile(file)
+	if os.IsNotExist(err) {
+		return l, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read lock file")
+	}
+	err = json.Unmarshal(data, &l)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read lock file")
+	}
+	return l, nil
+}
+
+// writeLock writes lock file, urls are sorted by json encoder
+func writeLock(file string, l lock) error {
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(file, append(data, '\n'), 0644)
+}
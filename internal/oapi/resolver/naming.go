@@ -0,0 +1,173 @@
+package resolver
+
+import (
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+)
+
+// Naming strategies of exported components
+const (
+	// NamingBare uses name of type, ie. Item
+	NamingBare = "bare"
+	// NamingPackage prefixes name by package name, ie. itemsItem
+	NamingPackage = "package"
+	// NamingQualified uses qualified name of type, ie. items.Item
+	NamingQualified = "qualified"
+	// NamingTemplate uses Naming.Template
+	NamingTemplate = "template"
+)
+
+// invalid matches characters which are not allowed in component keys
+var invalid = regexp.MustCompile(`[^a-zA-Z0-9._-]`)
+
+// Naming configures how exported components are named.
+// Names given explicitly by commands are kept as they are.
+type Naming struct {
+	// Strategy is one of bare (default), package, qualified or template.
+	Strategy string `json:"strategy"`
+
+	// Template is text/template used by template strategy,
+	// it is executed with .Name, .Package and .PkgPath.
+	Template string `json:"template"`
+
+	// ResolveCollisions will qualify colliding names by package path,
+	// otherwise collisions are reported as errors.
+	ResolveCollisions bool `json:"resolveCollisions"`
+}
+
+// templateData is data available in naming template
+type templateData struct {
+	Name    string
+	Package string
+	PkgPath string
+}
+
+// Apply will name exported components according to naming strategy
+// and resolve collisions of names.
+func (n Naming) Apply(exp Exports) (Exports, error) {
+	var tpl *template.Template
+	switch n.Strategy {
+	case "", NamingBare, NamingPackage, NamingQualified:
+	case NamingTemplate:
+		var err error
+		tpl, err = template.New("naming").Parse(n.Template)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid naming template")
+		}
+	default:
+		return nil, errors.Errorf("unknown naming strategy %q", n.Strategy)
+	}
+
+	named := make(Exports, len(exp))
+	bare := make([]string, len(exp))
+	for i, e := range exp {
+		bare[i] = e.Name
+		if !e.Named {
+			name, err := n.name(e, tpl)
+			if err != nil {
+				return nil, err
+			}
+			e.Name = name
+		}
+		named[i] = e
+	}
+	return named, n.collisions(named, bare)
+}
+
+// name returns name of component by naming strategy
+func (n Naming) name(e Pointer, tpl *template.Template) (string, error) {
+	pkg := packageName(e.PkgPath())
+	switch n.Strategy {
+	case NamingPackage:
+		return Sanitize(qualify([]string{pkg}, e.Name)), nil
+	case NamingQualified:
+		return Sanitize(pkg + "." + e.Name), nil
+	case NamingTemplate:
+		var b strings.Builder
+		err := tpl.Execute(&b, templateData{
+			Name:    e.Name,
+			Package: pkg,
+			PkgPath: e.PkgPath(),
+		})
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to name %q", e.Pointer.String())
+		}
+		return Sanitize(b.String()), nil
+	default:
+		return Sanitize(e.Name), nil
+	}
+}
+
+// collisions will report or resolve components of same kind having same
+// name. Colliding names are qualified by as many segments of package path
+// as needed to make them unique.
+func (n Naming) collisions(exp Exports, bare []string) error {
+	for level := 1; ; level++ {
+		groups := make(map[string][]int)
+		var keys []string
+		for i, e := range exp {
+			k := e.Path()
+			if _, ok := groups[k]; !ok {
+				keys = append(keys, k)
+			}
+			groups[k] = append(groups[k], i)
+		}
+
+		var collided bool
+		for _, k := range keys {
+			ii := groups[k]
+			if len(ii) < 2 {
+				continue
+			}
+			collided = true
+			renamed := false
+			if !n.ResolveCollisions {
+				return errors.Errorf("component %q is exported by %q and %q", exp[ii[0]].Name, exp[ii[0]].Pointer.String(), exp[ii[1]].Pointer.String())
+			}
+			for _, i := range ii {
+				segs := strings.Split(exp[i].PkgPath(), "/")
+				if exp[i].Named || level > len(segs) {
+					continue
+				}
+				exp[i].Name = Sanitize(qualify(segs[len(segs)-level:], bare[i]))
+				renamed = true
+			}
+			if !renamed {
+				return errors.Errorf("failed to resolve collision of component %q exported by %q and %q", exp[ii[0]].Name, exp[ii[0]].Pointer.String(), exp[ii[1]].Pointer.String())
+			}
+		}
+		if !collided {
+			return nil
+		}
+	}
+}
+
+// qualify prefixes name by segments of package path, ie. v1ItemsItem
+func qualify(segs []string, name string) string {
+	var b strings.Builder
+	for i, s := range append(segs, name) {
+		s = invalid.ReplaceAllString(s, "")
+		if i > 0 && len(s) > 0 {
+			s = strings.ToUpper(s[:1]) + s[1:]
+		}
+		b.WriteString(s)
+	}
+	return b.String()
+}
+
+// packageName returns last element of package path
+func packageName(path string) string {
+	if i := strings.LastIndex(path, "/"); i >= 0 {
+		return path[i+1:]
+	}
+	return path
+}
+
+// Sanitize replaces characters which are not allowed
+// in component keys (^[a-zA-Z0-9\.\-_]+$) by underscore.
+func Sanitize(name string) string {
+	return invalid.ReplaceAllString(name, "_")
+}
@@ -2,8 +2,10 @@ package resolver
 
 import (
 	"github.com/buypal/oapi-go/internal/container"
-	"github.com/buypal/oapi-go/internal/oapi/spec"
-	"github.com/buypal/oapi-go/internal/pointer"
+	"github.com/buypal/oapi-go/internal/diag"
+	"github.com/buypal/oapi-go/pointer"
+	"github.com/buypal/oapi-go/spec"
+	"github.com/pkg/errors"
 )
 
 var zero = container.Zero()
@@ -12,8 +14,17 @@ var zero = container.Zero()
 type Pointer struct {
 	Entity
 	pointer.Pointer
+	// Named is set if name was given explicitly,
+	// naming strategy is not applied then.
+	Named bool
+	// Wrap turns resolved schema into component of other
+	// kind, ie. response, it is not set for schemas.
+	Wrap Wrapper
 }
 
+// Wrapper wraps resolved schema into component of other kind.
+type Wrapper func(schema container.Container) (spec.Entiter, error)
+
 // Exports as list of components
 type Exports []Pointer
 
@@ -38,6 +49,25 @@ func (r Fn) call(p pointer.Pointer) (container.Container, error) {
 	return container.Make(x)
 }
 
+// Cached returns Fn which resolves every pointer only once, so pointers
+// discovered by Reused are not resolved again by Resolve. Copies of
+// resolved entities are returned, errors are not cached.
+func Cached(fn Fn) Fn {
+	cache := make(map[string]container.Container)
+	return func(p pointer.Pointer) (spec.Entiter, error) {
+		c, ok := cache[p.String()]
+		if !ok {
+			var err error
+			c, err = fn.call(p)
+			if err != nil {
+				return nil, err
+			}
+			cache[p.String()] = c
+		}
+		return Raw{Container: c.Clone()}, nil
+	}
+}
+
 // Resolve will resolve all references (pointers) in given scheme
 func Resolve(c container.Container, exp Exports, fn Fn) (container.Container, error) {
 	r := &resolver{
@@ -55,7 +85,75 @@ type resolver struct {
 }
 
 func (r resolver) resolve() (container.Container, error) {
-	return r.iterator(r.con, path{}, 0)
+	cx, err := r.iterator(r.con, path{}, 0)
+	if err != nil {
+		return cx, err
+	}
+	err = r.mappings(cx)
+	return cx, err
+}
+
+// mappings will update discriminator mappings which are pointing to
+// exported entities, so they refer to local document same as $refs.
+// Data are walked and updated in place, as keys of paths and mappings
+// may contain dots.
+func (r resolver) mappings(cx container.Container) error {
+	return walkObjects(cx.Data(), func(m map[string]interface{}) error {
+		d, ok := m["discriminator"].(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		mapping, ok := d["mapping"].(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		for k, v := range mapping {
+			s, ok := v.(string)
+			if !ok {
+				continue
+			}
+			p, err := pointer.Parse(s)
+			if err != nil {
+				return err
+			}
+			if !p.IsExternal() {
+				continue
+			}
+			ep, ok := r.exp.Get(p)
+			if !ok {
+				return errors.Errorf("discriminator mapping %q does not refer to exported component", s)
+			}
+			nptr := pointer.NewPointer()
+			nptr.Fragment = ep.Entity.Fragment()
+			mapping[k] = nptr.String()
+		}
+		return nil
+	})
+}
+
+// walkObjects calls fn for every object nested in v
+func walkObjects(v interface{}, fn func(map[string]interface{}) error) error {
+	switch x := v.(type) {
+	case map[string]interface{}:
+		err := fn(x)
+		if err != nil {
+			return err
+		}
+		for _, y := range x {
+			err = walkObjects(y, fn)
+			if err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, y := range x {
+			err := walkObjects(y, fn)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
 }
 
 func (r resolver) iterator(cx container.Container, pp path, dept int) (container.Container, error) {
@@ -87,12 +185,12 @@ func (r resolver) iterator(cx container.Container, pp path, dept int) (container
 
 		nc, err := r.res.call(p)
 		if err != nil {
-			return zero, err
+			return zero, diag.Via(p, err)
 		}
 
 		nc, err = r.iterator(nc, append(pp, p), dept+1)
 		if err != nil {
-			return zero, err
+			return zero, diag.Via(p, err)
 		}
 
 		err = r.set(cx, p, v.Key, nc)
@@ -110,6 +208,17 @@ func (r *resolver) set(cx container.Container, ptr pointer.Pointer, key string,
 	if !ok {
 		return ReplacePtr(cx, key, value)
 	}
+	if ep.Wrap != nil {
+		var w spec.Entiter
+		w, err = ep.Wrap(value)
+		if err != nil {
+			return diag.Via(ptr, err)
+		}
+		value, err = container.Make(w)
+		if err != nil {
+			return
+		}
+	}
 	e := EntityValue{
 		Entity: ep.Entity,
 		Value:  value,
@@ -132,11 +241,16 @@ func (r *resolver) setRecursive(cx container.Container, ptr pointer.Pointer, key
 type path []pointer.Pointer
 
 func (p path) has(px pointer.Pointer) bool {
+	return p.index(px) >= 0
+}
+
+// index returns position of pointer in path or -1
+func (p path) index(px pointer.Pointer) int {
 	for i := len(p) - 1; i >= 0; i-- {
 		el := p[i]
 		if el.String() == px.String() {
-			return true
+			return i
 		}
 	}
-	return false
+	return -1
 }
@@ -0,0 +1,339 @@
+package resolver
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/buypal/oapi-go/internal/container"
+	"github.com/buypal/oapi-go/internal/diag"
+	"github.com/buypal/oapi-go/pointer"
+	"github.com/buypal/oapi-go/spec"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveDiscriminatorMapping(t *testing.T) {
+	c, err := container.ReadYAML([]byte(`
+paths:
+  /v1.2/shapes/{name}.json:
+    get:
+      responses:
+        "200":
+          content:
+            application/json:
+              schema:
+                $ref: "go://test#/Shape"
+`))
+	require.NoError(t, err)
+
+	circle := pointer.MustParse("go://test#/Circle")
+	exp := Exports{{
+		Pointer: circle,
+		Entity:  Entity{Entity: spec.SchemaKind, Name: "Circle"},
+	}}
+
+	cnt, err := Resolve(c, exp, func(p pointer.Pointer) (spec.Entiter, error) {
+		switch p.String() {
+		case "go://test#/Shape":
+			return spec.Schema{
+				OneOf: []*spec.Schema{{Refable: spec.Refable{Ref: &circle}}},
+				Discriminator: &spec.Discriminator{
+					PropertyName: "kind",
+					Mapping:      map[string]string{"circle": circle.String(), "circle.v1": circle.String()},
+				},
+			}, nil
+		default:
+			return spec.Schema{Type: spec.TypeObject}, nil
+		}
+	})
+	require.NoError(t, err)
+
+	s := cnt.Path(container.SliceToDotPath([]string{"paths", "/v1.2/shapes/{name}.json", "get", "responses", "200", "content", "application/json", "schema"}))
+	require.Equal(t, "#/components/schemas/Circle", s.Path("oneOf.0.$ref").Data())
+	require.Equal(t, "#/components/schemas/Circle", s.Path("discriminator.mapping.circle").Data())
+	require.Equal(t, "#/components/schemas/Circle", s.Path("discriminator.mapping.circle~1v1").Data())
+	require.Len(t, cnt.Path("paths").Data(), 1)
+	require.Equal(t, "object", cnt.Path("components.schemas.Circle.type").Data())
+}
+
+func TestResolveErrorChain(t *testing.T) {
+	c, err := container.ReadYAML([]byte(`
+components:
+  schemas:
+    A:
+      $ref: "go://test#/A"
+`))
+	require.NoError(t, err)
+
+	b := pointer.MustParse("go://test#/B")
+	_, err = Resolve(c, nil, func(p pointer.Pointer) (spec.Entiter, error) {
+		switch p.String() {
+		case "go://test#/A":
+			return spec.Schema{Items: &spec.Schema{Refable: spec.Refable{Ref: &b}}}, nil
+		default:
+			return nil, errors.New("unsupported type")
+		}
+	})
+	require.Error(t, err)
+	require.Equal(t, []pointer.Pointer{pointer.MustParse("go://test#/A"), b}, diag.ChainOf(err))
+	require.Equal(t, "unsupported type (via go://test#/A -> go://test#/B)", err.Error())
+}
+
+func TestNaming(t *testing.T) {
+	export := func(ptr, name string, named bool) Pointer {
+		return Pointer{
+			Pointer: pointer.MustParse(ptr),
+			Entity:  Entity{Entity: spec.SchemaKind, Name: name},
+			Named:   named,
+		}
+	}
+	names := func(exp Exports) (nn []string) {
+		for _, e := range exp {
+			nn = append(nn, e.Name)
+		}
+		return
+	}
+
+	exp := Exports{
+		export("go://example.com/v1/items#/Item", "Item", false),
+		export("go://example.com/v2/items#/Item", "Item", false),
+		export("go://example.com/users#/User", "User", false),
+		export("go://example.com/users#/Page[Item]", "PageItem", false),
+		export("go://example.com/users#/Admin", "Root", true),
+	}
+
+	_, err := Naming{}.Apply(exp)
+	require.Error(t, err)
+
+	nn, err := Naming{ResolveCollisions: true}.Apply(exp)
+	require.NoError(t, err)
+	require.Equal(t, []string{"v1ItemsItem", "v2ItemsItem", "User", "PageItem", "Root"}, names(nn))
+
+	nn, err = Naming{Strategy: NamingPackage, ResolveCollisions: true}.Apply(exp)
+	require.NoError(t, err)
+	require.Equal(t, []string{"v1ItemsItem", "v2ItemsItem", "usersUser", "usersPageItem", "Root"}, names(nn))
+
+	nn, err = Naming{Strategy: NamingQualified}.Apply(exp[2:])
+	require.NoError(t, err)
+	require.Equal(t, []string{"users.User", "users.PageItem", "Root"}, names(nn))
+
+	nn, err = Naming{Strategy: NamingTemplate, Template: "{{.PkgPath}}/{{.Name}}"}.Apply(exp[2:])
+	require.NoError(t, err)
+	require.Equal(t, []string{"example.com_users_User", "example.com_users_PageItem", "Root"}, names(nn))
+
+	_, err = Naming{Strategy: "unknown"}.Apply(exp)
+	require.Error(t, err)
+}
+
+func TestReused(t *testing.T) {
+	c, err := container.ReadYAML([]byte(`
+paths:
+  /nodes:
+    get:
+      responses:
+        "200":
+          content:
+            application/json:
+              schema:
+                $ref: "go://test#/Node"
+  /items:
+    get:
+      responses:
+        "200":
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: "go://test#/Item"
+`))
+	require.NoError(t, err)
+
+	ref := func(s string) *spec.Schema {
+		p := pointer.MustParse(s)
+		return &spec.Schema{Refable: spec.Refable{Ref: &p}}
+	}
+
+	fn := func(p pointer.Pointer) (spec.Entiter, error) {
+		switch p.String() {
+		case "go://test#/Node":
+			return spec.Schema{
+				Type: spec.TypeObject,
+				Properties: map[string]*spec.Schema{
+					"children": spec.ArrayProperty(ref("go://test#/Node")),
+					"item":     ref("go://test#/Item"),
+					"meta":     ref("go://test#/Meta"),
+				},
+			}, nil
+		default:
+			return spec.Schema{Type: spec.TypeObject}, nil
+		}
+	}
+
+	pp, err := Reused(c, fn)
+	require.NoError(t, err)
+	require.Len(t, pp, 2)
+	require.Equal(t, "go://test#/Item", pp[0].String())
+	require.Equal(t, "go://test#/Node", pp[1].String())
+}
+
+func TestFileFn(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "schemas"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "schemas", "error.yaml"), []byte(`
+Error:
+  type: object
+  properties:
+    code:
+      $ref: "#/Code"
+    meta:
+      $ref: "../meta.json#/Meta"
+Code:
+  type: integer
+`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "meta.json"), []byte(`{"Meta": {"type": "object"}}`), 0644))
+
+	c, err := container.ReadYAML([]byte(`
+paths:
+  /items:
+    get:
+      responses:
+        default:
+          content:
+            application/json:
+              schema:
+                $ref: "./schemas/error.yaml#/Error"
+`))
+	require.NoError(t, err)
+
+	// references are made absolute by specs scanner
+	ptr, err := pointer.Parse("./schemas/error.yaml#/Error")
+	require.NoError(t, err)
+	key := "paths./items.get.responses.default.content.application/json.schema.$ref"
+	require.NoError(t, c.SetP(key, ptr.InDir(dir).String()))
+
+	cnt, err := Resolve(c, nil, FileFn())
+	require.NoError(t, err)
+
+	data, err := cnt.Path("paths./items.get.responses.default.content.application/json.schema").MarshalYAML()
+	require.NoError(t, err)
+	require.Equal(t, `properties:
+  code:
+    type: integer
+  meta:
+    type: object
+type: object
+`, string(data))
+
+	// fragments are copies, cached file is not changed
+	fn := FileFn()
+	meta := pointer.MustParse("file://" + filepath.ToSlash(dir) + "/meta.json#/Meta")
+	e, err := fn(meta)
+	require.NoError(t, err)
+	require.NoError(t, e.(Raw).SetP("type", "string"))
+	e, err = fn(meta)
+	require.NoError(t, err)
+	require.Equal(t, "object", e.(Raw).Path("type").Data())
+
+	_, err = FileFn()(pointer.MustParse("file://" + filepath.ToSlash(dir) + "/meta.json#/Missing"))
+	require.Error(t, err)
+}
+
+func TestHTTPFn(t *testing.T) {
+	docs := map[string]string{
+		"/errors.yaml": `
+Error:
+  type: object
+  properties:
+    code:
+      $ref: "#/Code"
+    meta:
+      $ref: "meta.json#/Meta"
+Code:
+  type: integer
+`,
+		"/meta.json": `{"Meta": {"type": "object"}}`,
+	}
+	var hits int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		doc, ok := docs[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(doc))
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	o := HTTPOptions{
+		CacheDir: filepath.Join(dir, "cache"),
+		LockFile: filepath.Join(dir, "oapi.lock"),
+	}
+
+	c, err := container.ReadYAML([]byte(`
+components:
+  schemas:
+    Response:
+      $ref: "` + srv.URL + `/errors.yaml#/Error"
+`))
+	require.NoError(t, err)
+
+	expected := `properties:
+  code:
+    type: integer
+  meta:
+    type: object
+type: object
+`
+	cnt, err := Resolve(c, nil, HTTPFn(context.Background(), o))
+	require.NoError(t, err)
+	data, err := cnt.Path("components.schemas.Response").MarshalYAML()
+	require.NoError(t, err)
+	require.Equal(t, expected, string(data))
+	require.Equal(t, 2, hits)
+	require.FileExists(t, o.LockFile)
+
+	// offline documents are served from cache
+	o.Offline = true
+	cnt, err = Resolve(c, nil, HTTPFn(context.Background(), o))
+	require.NoError(t, err)
+	data, err = cnt.Path("components.schemas.Response").MarshalYAML()
+	require.NoError(t, err)
+	require.Equal(t, expected, string(data))
+	require.Equal(t, 2, hits)
+
+	// changed content is rejected by lock file
+	o.Offline = false
+	docs["/meta.json"] = `{"Meta": {"type": "string"}}`
+	_, err = Resolve(c, nil, HTTPFn(context.Background(), o))
+	require.Error(t, err)
+
+	// missing documents
+	_, err = HTTPFn(context.Background(), o)(pointer.MustParse(srv.URL + "/missing.yaml#/X"))
+	require.Error(t, err)
+	o.Offline = true
+	_, err = HTTPFn(context.Background(), o)(pointer.MustParse(srv.URL + "/missing.yaml#/X"))
+	require.Error(t, err)
+
+	// fragments are copies, cached document is not changed
+	fn := HTTPFn(context.Background(), HTTPOptions{})
+	code := pointer.MustParse(srv.URL + "/errors.yaml#/Code")
+	e, err := fn(code)
+	require.NoError(t, err)
+	require.NoError(t, e.(Raw).SetP("type", "string"))
+	e, err = fn(code)
+	require.NoError(t, err)
+	require.Equal(t, "integer", e.(Raw).Path("type").Data())
+
+	// fetching is canceled by context
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = HTTPFn(ctx, HTTPOptions{})(code)
+	require.True(t, errors.Is(err, context.Canceled), err)
+}
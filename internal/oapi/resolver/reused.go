@@ -0,0 +1,109 @@
+package resolver
+
+import (
+	"sort"
+
+	"github.com/buypal/oapi-go/internal/container"
+	"github.com/buypal/oapi-go/internal/diag"
+	"github.com/buypal/oapi-go/pointer"
+)
+
+// Reused will find schemas of named types which are referenced more than
+// once or which are part of a cycle. Such schemas are better exported as
+// components than inlined, recursive references of schemas which are not
+// exported are replaced by empty object.
+func Reused(c container.Container, fn Fn) ([]pointer.Pointer, error) {
+	d := &discovery{
+		res:    fn,
+		refs:   make(map[string]int),
+		cyclic: make(map[string]bool),
+		seen:   make(map[string]container.Container),
+		ptrs:   make(map[string]pointer.Pointer),
+	}
+	err := d.walk(c, path{})
+	if err != nil {
+		return nil, err
+	}
+
+	var pp []pointer.Pointer
+	for k, p := range d.ptrs {
+		if d.refs[k] < 2 && !d.cyclic[k] {
+			continue
+		}
+		if !isNamedStruct(p, d.seen[k]) {
+			continue
+		}
+		pp = append(pp, p)
+	}
+	sort.Slice(pp, func(i, j int) bool {
+		return pp[i].String() < pp[j].String()
+	})
+	return pp, nil
+}
+
+type discovery struct {
+	res Fn
+	// number of references of pointer
+	refs map[string]int
+	// pointers taking part in cycle
+	cyclic map[string]bool
+	// resolved pointers
+	seen map[string]container.Container
+	ptrs map[string]pointer.Pointer
+}
+
+// walk counts references, content of every pointer is walked only
+// once as it is either inlined once or exported.
+func (d *discovery) walk(cx container.Container, pp path) error {
+	refs, err := container.ExtractKey(cx, "$ref")
+	if err != nil {
+		return err
+	}
+	for _, v := range refs {
+		s, ok := v.Val.(string)
+		if !ok {
+			continue
+		}
+		p, err := pointer.Parse(s)
+		if err != nil {
+			return err
+		}
+		if !p.IsExternal() {
+			continue
+		}
+		k := p.String()
+		d.refs[k]++
+
+		if i := pp.index(p); i >= 0 {
+			for _, x := range pp[i:] {
+				d.cyclic[x.String()] = true
+			}
+			continue
+		}
+		if _, ok := d.seen[k]; ok {
+			continue
+		}
+
+		nc, err := d.res.call(p)
+		if err != nil {
+			return diag.Via(p, err)
+		}
+		d.seen[k] = nc
+		d.ptrs[k] = p
+
+		err = d.walk(nc, append(pp, p))
+		if err != nil {
+			return diag.Via(p, err)
+		}
+	}
+	return nil
+}
+
+// isNamedStruct reports if pointer refers to named go type
+// described as object.
+func isNamedStruct(p pointer.Pointer, c container.Container) bool {
+	if p.Scheme != "go" || p.Fragment.Len() != 1 {
+		return false
+	}
+	return c.Path("type").Data() == "object" || c.ExistsP("allOf")
+}
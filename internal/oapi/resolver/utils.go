@@ -6,8 +6,8 @@ import (
 	"strings"
 
 	"github.com/buypal/oapi-go/internal/container"
-	"github.com/buypal/oapi-go/internal/oapi/spec"
-	"github.com/buypal/oapi-go/internal/pointer"
+	"github.com/buypal/oapi-go/pointer"
+	"github.com/buypal/oapi-go/spec"
 )
 
 // Entity is named entity.
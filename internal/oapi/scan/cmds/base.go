@@ -1,6 +1,10 @@
 package cmds
 
-import "golang.org/x/tools/go/packages"
+import (
+	"go/token"
+
+	"golang.org/x/tools/go/packages"
+)
 
 // CmdBase is base command
 type CmdBase struct {
@@ -8,6 +12,9 @@ type CmdBase struct {
 	cmd    CmdKind
 	args   Args
 	pkg    *packages.Package
+	pos    token.Position
+	// fn is name of func documented by command
+	fn string
 }
 
 // GetCmd returns matched command.
@@ -19,3 +26,8 @@ func (c CmdBase) GetCmd() CmdKind {
 func (c CmdBase) GetArgs() Args {
 	return c.args
 }
+
+// Pos returns position of command in go file.
+func (c CmdBase) Pos() token.Position {
+	return c.pos
+}
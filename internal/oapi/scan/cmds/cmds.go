@@ -3,9 +3,11 @@ package cmds
 import (
 	"fmt"
 	"go/ast"
+	"go/token"
 	"strings"
 
-	"github.com/buypal/oapi-go/internal/pointer"
+	"github.com/buypal/oapi-go/internal/pkgutil"
+	"github.com/buypal/oapi-go/pointer"
 	"github.com/pkg/errors"
 	"golang.org/x/tools/go/packages"
 )
@@ -20,6 +22,22 @@ const (
 	RootKind CmdKind = ":root"
 	// SchemaKind is schema command
 	SchemaKind CmdKind = ":schema"
+	// TypeKind is type command
+	TypeKind CmdKind = ":type"
+	// OneOfKind is oneof command
+	OneOfKind CmdKind = ":oneof"
+	// ExtensionKind is extension command
+	ExtensionKind CmdKind = ":extension"
+	// OperationKind is operation command
+	OperationKind CmdKind = ":operation"
+	// ResponseKind is response command
+	ResponseKind CmdKind = ":response"
+	// ParameterKind is parameter command
+	ParameterKind CmdKind = ":parameter"
+	// RequestBodyKind is request body command
+	RequestBodyKind CmdKind = ":requestBody"
+	// HeaderKind is header command
+	HeaderKind CmdKind = ":header"
 )
 
 // Commander interface allows to unite and cast given comments
@@ -33,6 +51,22 @@ type Commander interface {
 // parsed comments as a strings.
 func ParseCommentGroup(gg *ast.CommentGroup) []string {
 	cc := []string{}
+	for _, cmt := range commentsOf(gg) {
+		cc = append(cc, cmt.text)
+	}
+	return cc
+}
+
+// comment is command comment with its position
+type comment struct {
+	text string
+	pos  token.Pos
+	// fn is name of func documented by comment
+	fn string
+}
+
+// commentsOf returns command comments of group without prefix
+func commentsOf(gg *ast.CommentGroup) (cc []comment) {
 	for _, cmt := range gg.List {
 		c := cmt.Text[2:]
 		hasPrefix := strings.HasPrefix(c, prefix)
@@ -41,13 +75,19 @@ func ParseCommentGroup(gg *ast.CommentGroup) []string {
 		}
 		c = c[len(prefix):]
 		c = strings.TrimRight(c, " \n")
-		cc = append(cc, c)
+		cc = append(cc, comment{text: c, pos: cmt.Pos()})
 	}
 	return cc
 }
 
 // Parse will parse pacage with given comment, returning command.
 func Parse(pkg *packages.Package, comment string) (s Commander, err error) {
+	return parse(pkg, comment, token.Position{}, "")
+}
+
+// parse will parse command placed at given position
+// in doc comment of func fn (if any).
+func parse(pkg *packages.Package, comment string, pos token.Position, fn string) (s Commander, err error) {
 	cmd := strings.Split(comment, " ")
 	if len(cmd) == 0 {
 		err = errors.New("failed to parse cmd")
@@ -63,6 +103,8 @@ func Parse(pkg *packages.Package, comment string) (s Commander, err error) {
 		args:   newArguments(cmd[1:]),
 		pkg:    pkg,
 		origin: comment,
+		pos:    pos,
+		fn:     fn,
 	}
 
 	switch k {
@@ -72,12 +114,54 @@ func Parse(pkg *packages.Package, comment string) (s Commander, err error) {
 	case RootKind:
 		s, err = NewCmdRoot(r)
 		return
+	case TypeKind:
+		s, err = NewCmdType(r)
+		return
+	case OneOfKind:
+		s, err = NewCmdOneOf(r)
+		return
+	case ExtensionKind:
+		s, err = NewCmdExtension(r)
+		return
+	case OperationKind:
+		s, err = NewCmdOperation(r)
+		return
+	case ResponseKind, ParameterKind, RequestBodyKind, HeaderKind:
+		s, err = NewCmdComponent(r)
+		return
 	default:
 		err = errors.New(fmt.Sprintf("invalid open api cmd: %q", cmd))
 		return
 	}
 }
 
+// makePtr creates pointer from command argument, argument is either
+// full uri or name of type in package of command.
+func makePtr(cmd CmdBase, a string) (pointer.Pointer, error) {
+	if strings.Contains(a, "://") || strings.Contains(a, "#") {
+		p, err := pointer.Parse(a)
+		if err != nil {
+			return p, err
+		}
+		// go://#/Type refers to type in package of command
+		if p.Scheme == "go" && p.PkgPath() == "" {
+			pf, err := pointer.NewGoPointer(cmd.pkg.Types.Path(), "")
+			pf.Fragment = p.Fragment
+			return pf, err
+		}
+		if !p.IsRelative() {
+			return p, nil
+		}
+		// relative paths are resolved against package directory
+		dir, err := pkgutil.GetPkgPath(cmd.pkg)
+		if err != nil {
+			return p, err
+		}
+		return p.InDir(dir), nil
+	}
+	return pointer.NewGoPointer(cmd.pkg.Types.Path(), a)
+}
+
 // List is list of commands
 type List []Commander
 
@@ -88,6 +172,19 @@ func (cc List) Pointers() pointer.Pointers {
 		switch n := c.(type) {
 		case CmdSchema:
 			x[n.Ptr.String()] = n.Ptr
+		case CmdComponent:
+			x[n.Ptr.String()] = n.Ptr
+		case CmdOneOf:
+			x[n.OneOf.Ptr.String()] = n.OneOf.Ptr
+			for _, v := range n.OneOf.Variants {
+				x[v.Ptr.String()] = v.Ptr
+			}
+		case CmdExtension:
+			x[n.Extension.Ptr.String()] = n.Extension.Ptr
+		case CmdOperation:
+			for _, p := range n.Pointers() {
+				x[p.String()] = p
+			}
 		}
 	}
 	return x
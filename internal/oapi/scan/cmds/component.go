@@ -0,0 +1,126 @@
+package cmds
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/buypal/oapi-go/internal/container"
+	"github.com/buypal/oapi-go/internal/oapi/scan/types"
+	"github.com/buypal/oapi-go/pointer"
+	"github.com/buypal/oapi-go/spec"
+	"github.com/pkg/errors"
+)
+
+const (
+	inArg   = "in="
+	nameArg = "name="
+)
+
+// components are kinds of components exported by commands
+var components = map[CmdKind]spec.Entity{
+	ResponseKind:    spec.ResponseKind,
+	ParameterKind:   spec.ParameterKind,
+	RequestBodyKind: spec.RequestBodyKind,
+	HeaderKind:      spec.HeaderKind,
+}
+
+// CmdComponent is command exporting schema of type as component of
+// other kind than schema, references to its pointer refer to component.
+// It has syntax: //openapi:<response|requestBody|header> [name] <name|uri>
+// and //openapi:parameter [name] <name|uri> in=<query|header|path|cookie>
+// [name=<param>]. Bodies are json, description of response is description of schema
+// or its name. Parameter is named by component unless name is given.
+type CmdComponent struct {
+	CmdBase
+	Entity spec.Entity
+	Name   string
+	Ptr    pointer.Pointer
+	// Named is set if name was given explicitly
+	Named bool
+	// In is location of parameter
+	In string
+	// Param is name of parameter
+	Param string
+}
+
+// NewCmdComponent creates new component command
+func NewCmdComponent(cmd CmdBase) (s Commander, err error) {
+	rerr := errors.Errorf("failed to parse openapi%s comment: %q", cmd.cmd, cmd.origin)
+
+	sx := CmdComponent{CmdBase: cmd, Entity: components[cmd.cmd]}
+
+	var args []string
+	for _, a := range cmd.args {
+		switch {
+		case len(a) == 0:
+		case cmd.cmd == ParameterKind && strings.HasPrefix(a, inArg):
+			sx.In = strings.TrimPrefix(a, inArg)
+		case cmd.cmd == ParameterKind && strings.HasPrefix(a, nameArg):
+			sx.Param = strings.TrimPrefix(a, nameArg)
+		default:
+			args = append(args, a)
+		}
+	}
+
+	switch len(args) {
+	case 1:
+		sx.Ptr, err = makePtr(cmd, args[0])
+		if err != nil {
+			return nil, err
+		}
+		last, _ := sx.Ptr.Fragment.Last()
+		sx.Name = types.ComponentName(last)
+	case 2:
+		sx.Name = args[0]
+		sx.Named = true
+		sx.Ptr, err = makePtr(cmd, args[1])
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, rerr
+	}
+
+	if cmd.cmd != ParameterKind {
+		return sx, nil
+	}
+	switch sx.In {
+	case spec.InQuery, spec.InHeader, spec.InPath, spec.InCookie:
+	default:
+		return nil, rerr
+	}
+	if len(sx.Param) == 0 {
+		sx.Param = sx.Name
+	}
+	return sx, nil
+}
+
+// Wrap wraps schema of type into component
+func (c CmdComponent) Wrap(schema container.Container) (spec.Entiter, error) {
+	s := &spec.Schema{}
+	err := json.Unmarshal(schema.Bytes(), s)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read schema of %s %q", c.Entity.Key(), c.Name)
+	}
+
+	switch c.Entity {
+	case spec.ResponseKind:
+		desc := s.Description
+		if len(desc) == 0 {
+			desc = c.Name
+		}
+		return spec.JSONResponse(s).WithDescription(desc), nil
+	case spec.RequestBodyKind:
+		rb := spec.JSONRequestBody(s)
+		rb.Required = true
+		return rb, nil
+	case spec.HeaderKind:
+		return &spec.Header{Schema: s}, nil
+	case spec.ParameterKind:
+		p := &spec.Parameter{Name: c.Param, In: c.In, Schema: s}
+		p.Required = c.In == spec.InPath
+		return p, nil
+	default:
+		return nil, errors.Errorf("unsupported component kind %q", c.Entity.Key())
+	}
+}
@@ -0,0 +1,109 @@
+package cmds
+
+import (
+	"go/token"
+	"go/types"
+	"testing"
+
+	"github.com/buypal/oapi-go/internal/container"
+	"github.com/buypal/oapi-go/spec"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
+)
+
+func TestComponent(t *testing.T) {
+	pkg := &packages.Package{PkgPath: "test", Types: types.NewPackage("test", "test")}
+
+	schema, err := container.ReadYAML([]byte(`
+type: object
+description: Error of request
+properties:
+  message:
+    type: string
+`))
+	require.NoError(t, err)
+
+	tcases := []struct {
+		cmd      string
+		entity   spec.Entity
+		name     string
+		expected string
+	}{
+		{
+			cmd:    ":response NotFound go://#/ErrorBody",
+			entity: spec.ResponseKind,
+			name:   "NotFound",
+			expected: `
+description: Error of request
+content:
+  application/json:
+    schema: {type: object, description: Error of request, properties: {message: {type: string}}}
+`,
+		},
+		{
+			cmd:    ":requestBody ErrorBody",
+			entity: spec.RequestBodyKind,
+			name:   "ErrorBody",
+			expected: `
+required: true
+content:
+  application/json:
+    schema: {type: object, description: Error of request, properties: {message: {type: string}}}
+`,
+		},
+		{
+			cmd:    ":header RequestID go://#/ErrorBody",
+			entity: spec.HeaderKind,
+			name:   "RequestID",
+			expected: `
+schema: {type: object, description: Error of request, properties: {message: {type: string}}}
+`,
+		},
+		{
+			cmd:    ":parameter ID ErrorBody in=path name=id",
+			entity: spec.ParameterKind,
+			name:   "ID",
+			expected: `
+name: id
+in: path
+required: true
+schema: {type: object, description: Error of request, properties: {message: {type: string}}}
+`,
+		},
+	}
+
+	for _, tc := range tcases {
+		cmd, err := parse(pkg, tc.cmd, token.Position{}, "")
+		require.NoError(t, err, tc.cmd)
+		c, ok := cmd.(CmdComponent)
+		require.True(t, ok, tc.cmd)
+		require.Equal(t, tc.entity, c.Entity, tc.cmd)
+		require.Equal(t, tc.name, c.Name, tc.cmd)
+		require.Equal(t, "go://test#/ErrorBody", c.Ptr.String(), tc.cmd)
+
+		e, err := c.Wrap(schema)
+		require.NoError(t, err, tc.cmd)
+		require.Equal(t, tc.entity, e.Entity(), tc.cmd)
+
+		x, err := container.Make(e)
+		require.NoError(t, err, tc.cmd)
+		expected, err := container.ReadYAML([]byte(tc.expected))
+		require.NoError(t, err, tc.cmd)
+		require.JSONEq(t, string(expected.Bytes()), string(x.Bytes()), tc.cmd)
+	}
+
+	cmd, err := parse(pkg, ":parameter Limit in=query", token.Position{}, "")
+	require.NoError(t, err)
+	require.Equal(t, "Limit", cmd.(CmdComponent).Param)
+
+	invalid := []string{
+		":response",
+		":response A B C",
+		":parameter Limit",
+		":parameter Limit in=body",
+	}
+	for _, c := range invalid {
+		_, err = parse(pkg, c, token.Position{}, "")
+		require.Error(t, err, c)
+	}
+}
@@ -0,0 +1,45 @@
+package cmds
+
+import (
+	"strings"
+
+	"github.com/buypal/oapi-go/internal/oapi/scan/types"
+	"github.com/buypal/oapi-go/spec"
+	"github.com/pkg/errors"
+)
+
+// CmdExtension is command adding specification extensions to schema
+// of type. It has syntax: //openapi:extension <name|uri> <x-key=value> [...].
+// Values are json, values which are not valid json are strings.
+type CmdExtension struct {
+	CmdBase
+	Extension types.Extension
+}
+
+// NewCmdExtension creates new extension command
+func NewCmdExtension(cmd CmdBase) (s Commander, err error) {
+	rerr := errors.Errorf("failed to parse openapi:extension comment: %q", cmd.origin)
+
+	name, ok := cmd.args.Get(0)
+	if !ok || len(name) == 0 || cmd.args.Len() < 2 {
+		return nil, rerr
+	}
+
+	sx := CmdExtension{CmdBase: cmd}
+	sx.Extension.Ptr, err = makePtr(cmd, name)
+	if err != nil {
+		return nil, err
+	}
+	sx.Extension.Values = make(map[string]string)
+	for _, a := range cmd.args[1:] {
+		if len(a) == 0 {
+			continue
+		}
+		i := strings.Index(a, "=")
+		if i < 0 || !spec.IsExtension(a[:i]) {
+			return nil, rerr
+		}
+		sx.Extension.Values[a[:i]] = a[i+1:]
+	}
+	return sx, nil
+}
@@ -0,0 +1,62 @@
+package cmds
+
+import (
+	"strings"
+
+	"github.com/buypal/oapi-go/internal/oapi/scan/types"
+	"github.com/pkg/errors"
+)
+
+const discriminatorArg = "discriminator="
+
+// CmdOneOf is command declaring interface as polymorphic, every
+// value of such interface is one of listed types.
+// It has syntax: //openapi:oneof <interface> [type[=value] ...] [discriminator=<property>].
+// Value is what discriminator property holds for given type, by default
+// it is name of the type. If there are no types listed, implementations
+// of interface are searched for in scanned packages.
+type CmdOneOf struct {
+	CmdBase
+	OneOf types.OneOf
+}
+
+// NewCmdOneOf creates new oneof command
+func NewCmdOneOf(cmd CmdBase) (s Commander, err error) {
+	rerr := errors.Errorf("failed to parse openapi:oneof comment: %q", cmd.origin)
+
+	name, ok := cmd.args.Get(0)
+	if !ok || len(name) == 0 {
+		return nil, rerr
+	}
+
+	sx := CmdOneOf{CmdBase: cmd}
+	sx.OneOf.Ptr, err = makePtr(cmd, name)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, a := range cmd.args[1:] {
+		if len(a) == 0 {
+			continue
+		}
+		if strings.HasPrefix(a, discriminatorArg) {
+			sx.OneOf.Discriminator = strings.TrimPrefix(a, discriminatorArg)
+			if len(sx.OneOf.Discriminator) == 0 {
+				return nil, rerr
+			}
+			continue
+		}
+		var v types.Variant
+		ptr := a
+		// uris might contain '=' in query, value is always last
+		if i := strings.LastIndex(a, "="); i >= 0 && !strings.Contains(a[i:], "/") {
+			ptr, v.Value = a[:i], a[i+1:]
+		}
+		v.Ptr, err = makePtr(cmd, ptr)
+		if err != nil {
+			return nil, err
+		}
+		sx.OneOf.Variants = append(sx.OneOf.Variants, v)
+	}
+	return sx, nil
+}
@@ -0,0 +1,214 @@
+package cmds
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/buypal/oapi-go/internal/container"
+	"github.com/buypal/oapi-go/pointer"
+	"github.com/buypal/oapi-go/spec"
+	"github.com/pkg/errors"
+)
+
+const (
+	tagsArg        = "tags="
+	operationIDArg = "operationId="
+	requestArg     = "request="
+	responseArg    = "response"
+)
+
+// methods are methods of operations by their names in path item
+var methods = map[string]string{
+	http.MethodGet:     "get",
+	http.MethodPut:     "put",
+	http.MethodPost:    "post",
+	http.MethodDelete:  "delete",
+	http.MethodOptions: "options",
+	http.MethodHead:    "head",
+	http.MethodPatch:   "patch",
+	http.MethodTrace:   "trace",
+}
+
+// pathParam matches parameters of path template, ie. {id}
+var pathParam = regexp.MustCompile(`{([^{}]+)}`)
+
+// CmdOperation is command declaring operation, it is placed on handler
+// func and contributes paths entry which is merged with specs of packages.
+// It has syntax: //openapi:operation <method> <path> [tags=<tag,...>]
+// [operationId=<id>] [request=<name|uri>] response <code> [name|uri] [...].
+// Operation id defaults to name of func, parameters of path are strings
+// and responses are described by status text of their code, at least
+// one response has to be declared.
+type CmdOperation struct {
+	CmdBase
+	Method      string
+	Path        string
+	OperationID string
+	Tags        []string
+	Request     *pointer.Pointer
+	Responses   []Response
+}
+
+// Response is response of operation, pointer is
+// not set for responses without content.
+type Response struct {
+	Code string
+	Ptr  *pointer.Pointer
+}
+
+// NewCmdOperation creates new operation command
+func NewCmdOperation(cmd CmdBase) (s Commander, err error) {
+	rerr := errors.Errorf("failed to parse openapi:operation comment: %q", cmd.origin)
+
+	method, _ := cmd.args.Get(0)
+	path, _ := cmd.args.Get(1)
+	if _, ok := methods[strings.ToUpper(method)]; !ok || !strings.HasPrefix(path, "/") {
+		return nil, rerr
+	}
+
+	sx := CmdOperation{
+		CmdBase:     cmd,
+		Method:      strings.ToUpper(method),
+		Path:        path,
+		OperationID: cmd.fn,
+	}
+
+	args := cmd.args[2:]
+	for i := 0; i < len(args); i++ {
+		a := args[i]
+		switch {
+		case len(a) == 0:
+		case strings.HasPrefix(a, tagsArg):
+			for _, t := range strings.Split(strings.TrimPrefix(a, tagsArg), ",") {
+				if len(t) > 0 {
+					sx.Tags = append(sx.Tags, t)
+				}
+			}
+		case strings.HasPrefix(a, operationIDArg):
+			sx.OperationID = strings.TrimPrefix(a, operationIDArg)
+		case strings.HasPrefix(a, requestArg):
+			var p pointer.Pointer
+			p, err = makePtr(cmd, strings.TrimPrefix(a, requestArg))
+			if err != nil {
+				return nil, err
+			}
+			sx.Request = &p
+		case a == responseArg:
+			if i+1 >= len(args) || !isStatusCode(args[i+1]) {
+				return nil, rerr
+			}
+			i++
+			r := Response{Code: args[i]}
+			// pointer is optional, ie. response 204
+			if i+1 < len(args) && !isOperationArg(args[i+1]) {
+				i++
+				var p pointer.Pointer
+				p, err = makePtr(cmd, args[i])
+				if err != nil {
+					return nil, err
+				}
+				r.Ptr = &p
+			}
+			sx.Responses = append(sx.Responses, r)
+		default:
+			return nil, rerr
+		}
+	}
+	// responses are required by openapi
+	if len(sx.Responses) == 0 {
+		return nil, errors.Wrap(rerr, "at least one response is required")
+	}
+	return sx, nil
+}
+
+// isOperationArg reports if argument is keyword of operation command
+func isOperationArg(a string) bool {
+	return a == responseArg ||
+		strings.HasPrefix(a, tagsArg) ||
+		strings.HasPrefix(a, operationIDArg) ||
+		strings.HasPrefix(a, requestArg)
+}
+
+// isStatusCode reports if code is valid key of responses, ie. 200, 4XX or default
+func isStatusCode(code string) bool {
+	if code == "default" {
+		return true
+	}
+	if len(code) != 3 || code[0] < '1' || code[0] > '5' {
+		return false
+	}
+	if strings.ToUpper(code[1:]) == "XX" {
+		return true
+	}
+	_, err := strconv.Atoi(code)
+	return err == nil
+}
+
+// Pointers returns pointers of request and responses
+func (c CmdOperation) Pointers() (pp []pointer.Pointer) {
+	if c.Request != nil {
+		pp = append(pp, *c.Request)
+	}
+	for _, r := range c.Responses {
+		if r.Ptr != nil {
+			pp = append(pp, *r.Ptr)
+		}
+	}
+	return
+}
+
+// Operation describes operation, bodies are json.
+func (c CmdOperation) Operation() *spec.Operation {
+	op := &spec.Operation{
+		OperationID: c.OperationID,
+		Tags:        c.Tags,
+	}
+	for _, m := range pathParam.FindAllStringSubmatch(c.Path, -1) {
+		op.Parameters = append(op.Parameters, spec.PathParam(m[1], spec.StringProperty()))
+	}
+	if c.Request != nil {
+		op.RequestBody = spec.JSONRequestBody(refSchema(*c.Request))
+		op.RequestBody.Required = true
+	}
+	for _, r := range c.Responses {
+		if op.Responses == nil {
+			op.Responses = make(map[string]*spec.Response)
+		}
+		resp := &spec.Response{}
+		if r.Ptr != nil {
+			resp = spec.JSONResponse(refSchema(*r.Ptr))
+		}
+		op.Responses[r.Code] = resp.WithDescription(statusText(r.Code))
+	}
+	return op
+}
+
+// Container returns paths entry of operation
+func (c CmdOperation) Container() (container.Container, error) {
+	return container.Make(map[string]interface{}{
+		"paths": map[string]interface{}{
+			c.Path: map[string]interface{}{
+				methods[c.Method]: c.Operation(),
+			},
+		},
+	})
+}
+
+// refSchema returns schema referring to pointer
+func refSchema(p pointer.Pointer) *spec.Schema {
+	return &spec.Schema{Refable: spec.Refable{Ref: &p}}
+}
+
+// statusText returns description of response by its code
+func statusText(code string) string {
+	n, _ := strconv.Atoi(code)
+	if text := http.StatusText(n); len(text) > 0 {
+		return text
+	}
+	if code == "default" {
+		return "Default response"
+	}
+	return code + " response"
+}
@@ -0,0 +1,90 @@
+package cmds
+
+import (
+	"go/token"
+	"go/types"
+	"testing"
+
+	"github.com/buypal/oapi-go/internal/container"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
+)
+
+func TestOperation(t *testing.T) {
+	pkg := &packages.Package{PkgPath: "test", Types: types.NewPackage("test", "test")}
+
+	cmd, err := parse(pkg, ":operation GET /v1/items/{id} tags=items,public request=Item response 200 go://#/Item response 404 response default go://errors#/Error", token.Position{}, "GetItem")
+	require.NoError(t, err)
+	op, ok := cmd.(CmdOperation)
+	require.True(t, ok)
+	require.Equal(t, "GetItem", op.OperationID)
+	require.Len(t, op.Pointers(), 3)
+
+	c, err := op.Container()
+	require.NoError(t, err)
+	expected, err := container.ReadYAML([]byte(`
+paths:
+  /v1/items/{id}:
+    get:
+      operationId: GetItem
+      tags: [items, public]
+      parameters:
+      - name: id
+        in: path
+        required: true
+        schema:
+          type: string
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: go://test#/Item
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: go://test#/Item
+        "404":
+          description: Not Found
+        default:
+          description: Default response
+          content:
+            application/json:
+              schema:
+                $ref: go://errors#/Error
+`))
+	require.NoError(t, err)
+	require.JSONEq(t, string(expected.Bytes()), string(c.Bytes()))
+
+	cmd, err = parse(pkg, ":operation post /v1/items operationId=createItem response 201 Item", token.Position{}, "")
+	require.NoError(t, err)
+	require.Equal(t, "POST", cmd.(CmdOperation).Method)
+	require.Equal(t, "createItem", cmd.(CmdOperation).OperationID)
+
+	// dots in path are kept in key of path item
+	cmd, err = parse(pkg, ":operation GET /v1/files/{name}.json response 200 File", token.Position{}, "GetFile")
+	require.NoError(t, err)
+	c, err = cmd.(CmdOperation).Container()
+	require.NoError(t, err)
+	paths, err := c.Path("paths").ChildrenMap()
+	require.NoError(t, err)
+	require.Contains(t, paths, "/v1/files/{name}.json")
+	require.Len(t, paths, 1)
+
+	invalid := []string{
+		":operation GET /v1/items",
+		":operation GET /v1/items tags=items",
+		":operation FETCH /v1/items",
+		":operation GET v1/items",
+		":operation GET /v1/items response",
+		":operation GET /v1/items response 600 Item",
+		":operation GET /v1/items summary=Items response 200",
+	}
+	for _, c := range invalid {
+		_, err = parse(pkg, c, token.Position{}, "")
+		require.Error(t, err, c)
+	}
+}
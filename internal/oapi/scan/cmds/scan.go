@@ -2,9 +2,14 @@ package cmds
 
 import (
 	"go/ast"
+	"go/token"
+	"sort"
 
+	"github.com/buypal/oapi-go/internal/container"
+	"github.com/buypal/oapi-go/internal/diag"
 	"github.com/buypal/oapi-go/internal/oapi/resolver"
-	"github.com/buypal/oapi-go/internal/oapi/spec"
+	"github.com/buypal/oapi-go/internal/oapi/scan/types"
+	"github.com/buypal/oapi-go/spec"
 	"github.com/pkg/errors"
 	"golang.org/x/tools/go/packages"
 )
@@ -26,18 +31,31 @@ func NewScanner() *Scanner {
 // Scan will scan package and store info.
 func (r *Scanner) Scan(pkg *packages.Package) (err error) {
 	groups := []*ast.CommentGroup{}
-	comments := []string{}
+	comments := []comment{}
+	funcs := make(map[*ast.CommentGroup]string)
 	for _, s := range pkg.Syntax {
 		groups = append(groups, s.Comments...)
+		for _, d := range s.Decls {
+			if fd, ok := d.(*ast.FuncDecl); ok && fd.Doc != nil {
+				funcs[fd.Doc] = fd.Name.Name
+			}
+		}
 	}
 	for _, g := range groups {
-		comments = append(comments, ParseCommentGroup(g)...)
+		for _, c := range commentsOf(g) {
+			c.fn = funcs[g]
+			comments = append(comments, c)
+		}
 	}
 	var cc List
 	for _, c := range comments {
-		x, err := Parse(pkg, c)
+		var pos token.Position
+		if pkg.Fset != nil {
+			pos = pkg.Fset.Position(c.pos)
+		}
+		x, err := parse(pkg, c.text, pos, c.fn)
 		if err != nil {
-			return errors.Wrapf(err, "failed to parse openapi sytnax")
+			return diag.At(pos, errors.Wrapf(err, "failed to parse openapi syntax"))
 		}
 		cc = append(cc, x)
 	}
@@ -45,19 +63,30 @@ func (r *Scanner) Scan(pkg *packages.Package) (err error) {
 	return
 }
 
+// packages returns paths of scanned packages in sorted order,
+// so commands are processed same way on every run.
+func (r *Scanner) packages() []string {
+	pkgs := make([]string, 0, len(r.Commands))
+	for pkg := range r.Commands {
+		pkgs = append(pkgs, pkg)
+	}
+	sort.Strings(pkgs)
+	return pkgs
+}
+
 // ExportedComponents will provide exported components in a form
 // of resolver.Exports.
 func (r *Scanner) ExportedComponents() (exports resolver.Exports, err error) {
 	var cc List
-	for _, cmd := range r.Commands {
-		cc = append(cc, cmd...)
+	for _, pkg := range r.packages() {
+		cc = append(cc, r.Commands[pkg]...)
 	}
 
 	for _, cmd := range cc {
 		switch x := cmd.(type) {
 		case CmdSchema:
 			if _, ok := exports.Get(x.Ptr); ok {
-				err = errors.Errorf("schema of type %q already registered", x.Name)
+				err = diag.At(x.pos, errors.Errorf("schema of type %q already registered", x.Name))
 				return
 			}
 			entity := resolver.Entity{
@@ -67,8 +96,135 @@ func (r *Scanner) ExportedComponents() (exports resolver.Exports, err error) {
 			exp := resolver.Pointer{
 				Pointer: x.Ptr,
 				Entity:  entity,
+				Named:   x.Named,
 			}
 			exports = append(exports, exp)
+		case CmdComponent:
+			if _, ok := exports.Get(x.Ptr); ok {
+				err = diag.At(x.pos, errors.Errorf("type %q already registered as component", x.Ptr.String()))
+				return
+			}
+			exports = append(exports, resolver.Pointer{
+				Pointer: x.Ptr,
+				Entity: resolver.Entity{
+					Entity: x.Entity,
+					Name:   x.Name,
+				},
+				Named: x.Named,
+				Wrap:  x.Wrap,
+			})
+		}
+	}
+
+	// variants of polymorphic interfaces has to be exported,
+	// so discriminator can map to them
+	for _, cmd := range cc {
+		x, ok := cmd.(CmdOneOf)
+		if !ok {
+			continue
+		}
+		for _, v := range x.OneOf.Variants {
+			if _, ok := exports.Get(v.Ptr); ok {
+				continue
+			}
+			exports = append(exports, resolver.Pointer{
+				Pointer: v.Ptr,
+				Entity: resolver.Entity{
+					Entity: spec.SchemaKind,
+					Name:   v.Name(),
+				},
+			})
+		}
+	}
+	return
+}
+
+// OneOfs returns polymorphic interfaces declared by commands.
+func (r *Scanner) OneOfs() (oo []types.OneOf) {
+	for _, cc := range r.Commands {
+		for _, cmd := range cc {
+			if x, ok := cmd.(CmdOneOf); ok {
+				oo = append(oo, x.OneOf)
+			}
+		}
+	}
+	return
+}
+
+// Extensions returns extensions of types declared by commands,
+// extensions of same type are merged.
+func (r *Scanner) Extensions() (ee []types.Extension, err error) {
+	index := make(map[string]int)
+	for _, cc := range r.Commands {
+		for _, cmd := range cc {
+			x, ok := cmd.(CmdExtension)
+			if !ok {
+				continue
+			}
+			i, ok := index[x.Extension.Ptr.String()]
+			if !ok {
+				index[x.Extension.Ptr.String()] = len(ee)
+				ee = append(ee, types.Extension{Ptr: x.Extension.Ptr, Values: make(map[string]string)})
+				i = len(ee) - 1
+			}
+			for k, v := range x.Extension.Values {
+				if _, ok := ee[i].Values[k]; ok {
+					err = diag.At(x.pos, errors.Errorf("extension %q of %q already set", k, x.Extension.Ptr.String()))
+					return
+				}
+				ee[i].Values[k] = v
+			}
+		}
+	}
+	return
+}
+
+// MergePaths merges paths of operations declared by commands into
+// container, same as specs of packages operations can not collide.
+func (r *Scanner) MergePaths(c container.Container) error {
+	for _, pkg := range r.packages() {
+		for _, cmd := range r.Commands[pkg] {
+			x, ok := cmd.(CmdOperation)
+			if !ok {
+				continue
+			}
+			ops, err := x.Container()
+			if err != nil {
+				return diag.At(x.pos, err)
+			}
+			err = c.Merge(ops, container.MergeStrict)
+			if err != nil {
+				return diag.At(x.pos, errors.Wrapf(err, "operation %s %s", x.Method, x.Path))
+			}
+		}
+	}
+	return nil
+}
+
+// Overrides will provide schemas of types mapped by commands,
+// key is pointer same as for overrides from config.
+func (r *Scanner) Overrides() (overrides map[string]spec.Schema, err error) {
+	overrides = make(map[string]spec.Schema)
+	for _, cc := range r.Commands {
+		for _, cmd := range cc {
+			x, ok := cmd.(CmdType)
+			if !ok {
+				continue
+			}
+			if _, ok := overrides[x.Ptr.String()]; ok {
+				err = diag.At(x.pos, errors.Errorf("type %q already mapped", x.Ptr.String()))
+				return
+			}
+			var s *spec.Schema
+			s, err = types.TypeSchema(x.Type)
+			if err != nil {
+				err = diag.At(x.pos, errors.Wrapf(err, "failed to parse openapi:type comment: %q", x.origin))
+				return
+			}
+			if len(x.Format) > 0 {
+				s.Format = x.Format
+			}
+			overrides[x.Ptr.String()] = *s
 		}
 	}
 	return
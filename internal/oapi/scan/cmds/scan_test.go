@@ -0,0 +1,36 @@
+package cmds
+
+import (
+	"go/token"
+	"go/types"
+	"testing"
+
+	"github.com/buypal/oapi-go/internal/diag"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
+)
+
+func TestExportedComponentsOrder(t *testing.T) {
+	r := NewScanner()
+	for _, path := range []string{"c", "a", "b"} {
+		pkg := &packages.Package{PkgPath: path, Types: types.NewPackage(path, path)}
+		cmd, err := parse(pkg, ":schema "+path+"Item go://shared#/Item", token.Position{Filename: path + ".go", Line: 1}, "")
+		require.NoError(t, err)
+		r.Commands[path] = List{cmd}
+	}
+
+	// duplicate is always reported at second package in order
+	for i := 0; i < 10; i++ {
+		_, err := r.ExportedComponents()
+		require.Error(t, err)
+		pos, ok := diag.PosOf(err)
+		require.True(t, ok)
+		require.Equal(t, "b.go", pos.Filename)
+	}
+
+	delete(r.Commands, "b")
+	delete(r.Commands, "c")
+	exports, err := r.ExportedComponents()
+	require.NoError(t, err)
+	require.Equal(t, "aItem", exports[0].Name)
+}
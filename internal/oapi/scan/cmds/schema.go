@@ -1,9 +1,8 @@
 package cmds
 
 import (
-	"strings"
-
-	"github.com/buypal/oapi-go/internal/pointer"
+	"github.com/buypal/oapi-go/internal/oapi/scan/types"
+	"github.com/buypal/oapi-go/pointer"
 	"github.com/pkg/errors"
 )
 
@@ -12,10 +11,14 @@ import (
 // It has simple syntax: //oapi:schema <uri>,
 // causing schema to be exported at root of document
 // usually components.*.
+// Instantiated generic types are exported under name with type
+// arguments appended, //oapi:schema Page[Item] is exported as PageItem.
 type CmdSchema struct {
 	CmdBase
 	Name string
 	Ptr  pointer.Pointer
+	// Named is set if name was given explicitly
+	Named bool
 }
 
 // NewCmdSchema creates new command schema
@@ -29,20 +32,18 @@ func NewCmdSchema(cmd CmdBase) (s Commander, err error) {
 		return !a || len(s) == 0
 	}
 
-	makePtr := func(a string) (pointer.Pointer, error) {
-		if strings.Contains(a, "://") || strings.Contains(a, "#") {
-			return pointer.Parse(a)
-		}
-		return pointer.NewGoPointer(cmd.pkg.Types.Path(), a)
-	}
-
 	switch cmd.args.Len() {
 	case 1:
 		if invalid(nok, name) {
 			return nil, rerr
 		}
-		sx.Name = name
-		sx.Ptr, err = makePtr(name)
+		sx.Ptr, err = makePtr(cmd, name)
+		if err != nil {
+			return nil, err
+		}
+		// name of instantiated generic type is not valid component name
+		last, _ := sx.Ptr.Fragment.Last()
+		sx.Name = types.ComponentName(last)
 		return sx, nil
 	case 2:
 		if invalid(nok, name) {
@@ -52,7 +53,8 @@ func NewCmdSchema(cmd CmdBase) (s Commander, err error) {
 			return nil, rerr
 		}
 		sx.Name = name
-		sx.Ptr, err = makePtr(ptr)
+		sx.Named = true
+		sx.Ptr, err = makePtr(cmd, ptr)
 		return sx, nil
 	default:
 		return nil, errors.New("invalid number of arguments")
@@ -0,0 +1,39 @@
+package cmds
+
+import (
+	"github.com/buypal/oapi-go/pointer"
+	"github.com/pkg/errors"
+)
+
+// CmdType is command responsible for mapping of go type to
+// schema type. This is handy for types implementing json.Marshaler,
+// their wire format can not be figured out from go code.
+// It has simple syntax: //openapi:type <name|uri> <type> [format],
+// where type is one of types supported by oapi tag.
+type CmdType struct {
+	CmdBase
+	Ptr    pointer.Pointer
+	Type   string
+	Format string
+}
+
+// NewCmdType creates new command type
+func NewCmdType(cmd CmdBase) (s Commander, err error) {
+	name, _ := cmd.args.Get(0)
+	typ, _ := cmd.args.Get(1)
+	format, _ := cmd.args.Get(2)
+
+	if cmd.args.Len() < 2 || cmd.args.Len() > 3 {
+		return nil, errors.Errorf("failed to parse openapi:type comment: %q", cmd.origin)
+	}
+	if len(name) == 0 || len(typ) == 0 {
+		return nil, errors.Errorf("failed to parse openapi:type comment: %q", cmd.origin)
+	}
+
+	sx := CmdType{CmdBase: cmd, Type: typ, Format: format}
+	sx.Ptr, err = makePtr(cmd, name)
+	if err != nil {
+		return nil, err
+	}
+	return sx, nil
+}
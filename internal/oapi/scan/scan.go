@@ -1,8 +1,8 @@
 package scan
 
 import (
-	"github.com/buypal/oapi-go/internal/oapi/spec"
-	"github.com/buypal/oapi-go/internal/pointer"
+	"github.com/buypal/oapi-go/pointer"
+	"github.com/buypal/oapi-go/spec"
 	"golang.org/x/tools/go/packages"
 )
 
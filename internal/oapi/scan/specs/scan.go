@@ -1,10 +1,17 @@
 package specs
 
 import (
+	"go/token"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+
 	"github.com/buypal/oapi-go/internal/container"
+	"github.com/buypal/oapi-go/internal/diag"
 	"github.com/buypal/oapi-go/internal/pkgutil"
-	"github.com/buypal/oapi-go/internal/pointer"
+	"github.com/buypal/oapi-go/pointer"
 	"golang.org/x/tools/go/packages"
+	"gopkg.in/yaml.v3"
 )
 
 // Scanner allows to scan go packages and search for
@@ -13,12 +20,16 @@ type Scanner struct {
 	Containers container.Containers
 	patterns   []string
 	Pointers   pointer.Pointers
+	// Refs are positions of references in files by pointer,
+	// first reference of pointer is kept.
+	Refs map[string]token.Position
 }
 
 func NewScanner() *Scanner {
 	return &Scanner{
 		patterns: []string{"oapi.yaml", "oapi.yml", "oapi.json"},
 		Pointers: make(pointer.Pointers),
+		Refs:     make(map[string]token.Position),
 	}
 }
 
@@ -45,14 +56,20 @@ func (r *Scanner) Scan(pkg *packages.Package) (err error) {
 			return err
 		}
 
+		lines, err := refLines(c.FilePath())
+		if err != nil {
+			return err
+		}
+
 		for _, v := range refs {
 			x, ok := v.Val.(string)
 			if !ok {
 				continue
 			}
+			pos := token.Position{Filename: c.FilePath(), Line: lines[v.Key]}
 			p, err := pointer.Parse(x)
 			if err != nil {
-				return err
+				return diag.At(pos, err)
 			}
 
 			// this allow local references of go://#/Struct
@@ -62,7 +79,15 @@ func (r *Scanner) Scan(pkg *packages.Package) (err error) {
 				p = pf
 			}
 
+			// relative references are resolved against file
+			if p.IsRelative() {
+				p = p.InDir(filepath.Dir(c.FilePath()))
+			}
+
 			r.Pointers[p.String()] = p
+			if _, ok := r.Refs[p.String()]; !ok {
+				r.Refs[p.String()] = pos
+			}
 
 			err = c.SetP(v.Key, p.String())
 			if err != nil {
@@ -82,3 +107,57 @@ func (r *Scanner) Scan(pkg *packages.Package) (err error) {
 func (r *Scanner) Merge() (c container.Container, err error) {
 	return r.Containers.Sort().Merge(container.MergeStrict)
 }
+
+// refLines returns lines of references in file by dot path of their key.
+// Containers do not keep positions, therefore file is parsed again into
+// yaml nodes, json files are parsed same way as json is subset of yaml.
+func refLines(file string) (map[string]int, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	lines := make(map[string]int)
+	nodeRefs(&doc, nil, lines)
+	return lines, nil
+}
+
+// nodeRefs walks yaml nodes and stores line of every $ref key,
+// aliases and merge keys are walked under path they are used at.
+func nodeRefs(n *yaml.Node, path []string, lines map[string]int) {
+	switch n.Kind {
+	case yaml.DocumentNode:
+		for _, x := range n.Content {
+			nodeRefs(x, path, lines)
+		}
+	case yaml.AliasNode:
+		nodeRefs(n.Alias, path, lines)
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			k, v := n.Content[i], n.Content[i+1]
+			if k.Tag == "!!merge" {
+				if v.Kind == yaml.SequenceNode {
+					for _, x := range v.Content {
+						nodeRefs(x, path, lines)
+					}
+				} else {
+					nodeRefs(v, path, lines)
+				}
+				continue
+			}
+			p := append(path[:len(path):len(path)], k.Value)
+			if k.Value == "$ref" {
+				lines[container.SliceToDotPath(p)] = k.Line
+				continue
+			}
+			nodeRefs(v, p, lines)
+		}
+	case yaml.SequenceNode:
+		for i, x := range n.Content {
+			nodeRefs(x, append(path[:len(path):len(path)], strconv.Itoa(i)), lines)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package types
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+)
+
+// docs holds doc comments of declarations indexed by position
+// of their identifier. Position is what types.Object reports as Pos()
+// making it easy to get from type information back to comments.
+type docs map[token.Pos]string
+
+// add will collect doc comments of type declarations and struct fields
+// from given files.
+func (d docs) add(files ...*ast.File) {
+	for _, f := range files {
+		ast.Inspect(f, func(n ast.Node) bool {
+			switch x := n.(type) {
+			case *ast.GenDecl:
+				if x.Tok != token.TYPE {
+					return true
+				}
+				for _, s := range x.Specs {
+					ts, ok := s.(*ast.TypeSpec)
+					if !ok {
+						continue
+					}
+					cg := ts.Doc
+					// doc of single declaration is attached to GenDecl
+					if cg == nil && len(x.Specs) == 1 {
+						cg = x.Doc
+					}
+					if cg == nil {
+						cg = ts.Comment
+					}
+					d.set(ts.Name, cg)
+				}
+			case *ast.StructType:
+				for _, field := range x.Fields.List {
+					cg := field.Doc
+					if cg == nil {
+						cg = field.Comment
+					}
+					for _, name := range field.Names {
+						d.set(name, cg)
+					}
+				}
+			}
+			return true
+		})
+	}
+}
+
+func (d docs) set(ident *ast.Ident, cg *ast.CommentGroup) {
+	if cg == nil {
+		return
+	}
+	text := strings.TrimSpace(cg.Text())
+	if len(text) == 0 {
+		return
+	}
+	d[ident.Pos()] = text
+}
+
+// of returns doc comment of given object
+func (d docs) of(obj types.Object) (string, bool) {
+	if obj == nil || d == nil {
+		return "", false
+	}
+	text, ok := d[obj.Pos()]
+	return text, ok
+}
+
+// synopsis returns first sentence of doc comment, it is used
+// as title of schema.
+func synopsis(text string) string {
+	if i := strings.Index(text, "\n\n"); i >= 0 {
+		text = text[:i]
+	}
+	text = strings.Join(strings.Fields(text), " ")
+	for i := 0; i < len(text); i++ {
+		if text[i] != '.' {
+			continue
+		}
+		if i+1 == len(text) || text[i+1] == ' ' {
+			return text[:i+1]
+		}
+	}
+	return text
+}
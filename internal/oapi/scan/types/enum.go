@@ -0,0 +1,348 @@
+package types
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"sort"
+	"strconv"
+
+	"github.com/buypal/oapi-go/spec"
+	"github.com/buypal/oapi-go/tag"
+	"github.com/pkg/errors"
+)
+
+// enum2schema will convert named basic type to schema, if there are
+// constants of given type declared in its package they are listed as enum.
+func enum2schema(t *types.Named, m pointmap, tg tag.Tag) (s *spec.Schema, err error) {
+	u := t.Underlying().(*types.Basic)
+
+	consts := enumConsts(t, m)
+	if len(consts) == 0 {
+		return basic2schema(u.Kind(), tg)
+	}
+
+	info := u.Info()
+	switch {
+	case info&types.IsString != 0:
+		s, err = basic2schema(u.Kind(), tg)
+		if err != nil {
+			return
+		}
+		s.Enum, err = enumValues(consts, func(c *types.Const) (spec.Any, error) {
+			return spec.NewAny(constant.StringVal(c.Val())), nil
+		})
+		return
+
+	case info&types.IsInteger != 0 && m.mode&EnumStringer != 0 && hasStringer(t):
+		var names map[int64]string
+		names, err = evalStringer(t, m.files[t.Obj().Pkg().Path()], consts)
+		if err != nil {
+			return
+		}
+		s, err = basic2schema(types.String, tg)
+		if err != nil {
+			return
+		}
+		s.Enum, err = enumValues(consts, func(c *types.Const) (spec.Any, error) {
+			v, _ := constant.Int64Val(c.Val())
+			name, ok := names[v]
+			if !ok {
+				return nil, errors.Errorf("String() of %q does not cover constant %q", t.String(), c.Name())
+			}
+			return spec.NewAny(name), nil
+		})
+		return
+
+	case info&types.IsInteger != 0:
+		s, err = basic2schema(u.Kind(), tg)
+		if err != nil {
+			return
+		}
+		s.Enum, err = enumValues(consts, func(c *types.Const) (spec.Any, error) {
+			// values of fields with ",string" option are quoted
+			if tg.String {
+				return spec.NewAny(c.Val().ExactString()), nil
+			}
+			if v, ok := constant.Int64Val(c.Val()); ok {
+				return spec.NewAny(v), nil
+			}
+			v, _ := constant.Uint64Val(c.Val())
+			return spec.NewAny(v), nil
+		})
+		return
+
+	default:
+		return basic2schema(u.Kind(), tg)
+	}
+}
+
+// enumConsts will find all constants of given type in package declaring
+// the type. Only packages which were scanned (have syntax) are considered,
+// so types from standard library are not treated as enums.
+func enumConsts(t *types.Named, m pointmap) (cc []*types.Const) {
+	pkg := t.Obj().Pkg()
+	if pkg == nil {
+		return
+	}
+	if _, ok := m.files[pkg.Path()]; !ok {
+		return
+	}
+	scope := pkg.Scope()
+	for _, name := range scope.Names() {
+		c, ok := scope.Lookup(name).(*types.Const)
+		if !ok {
+			continue
+		}
+		if !types.Identical(c.Type(), t) {
+			continue
+		}
+		cc = append(cc, c)
+	}
+	// keep order of declaration
+	sort.SliceStable(cc, func(i, j int) bool {
+		return cc[i].Pos() < cc[j].Pos()
+	})
+	return
+}
+
+// enumValues will convert constants to enum values, skipping duplicates.
+func enumValues(cc []*types.Const, fn func(*types.Const) (spec.Any, error)) (vv []spec.Any, err error) {
+	seen := make(map[string]bool)
+	for _, c := range cc {
+		var v spec.Any
+		v, err = fn(c)
+		if err != nil {
+			return
+		}
+		if seen[string(v)] {
+			continue
+		}
+		seen[string(v)] = true
+		vv = append(vv, v)
+	}
+	return
+}
+
+// hasStringer reports if type has String() string method.
+func hasStringer(t *types.Named) bool {
+	_, ok := stringerMethod(t)
+	return ok
+}
+
+func stringerMethod(t *types.Named) (*types.Func, bool) {
+	obj, _, _ := types.LookupFieldOrMethod(t, false, t.Obj().Pkg(), "String")
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		return nil, false
+	}
+	sig := fn.Type().(*types.Signature)
+	if sig.Params().Len() != 0 || sig.Results().Len() != 1 {
+		return nil, false
+	}
+	if !types.Identical(sig.Results().At(0).Type(), types.Typ[types.String]) {
+		return nil, false
+	}
+	return fn, true
+}
+
+// evalStringer will statically evaluate String() method of given type.
+// We are not able to run the code, so only tables generated by stringer
+// tool and most common forms are supported:
+//
+//	switch s { case A: return "a" }
+//	return names[s] // where names is package level map, array or slice literal
+func evalStringer(t *types.Named, files []*ast.File, consts []*types.Const) (map[int64]string, error) {
+	names, ok, err := stringerTables(t, files, consts)
+	if ok || err != nil {
+		return names, err
+	}
+
+	fn, _ := stringerMethod(t)
+	rerr := errors.Errorf("failed to evaluate String() method of %q, it has to be generated by stringer, switch over constants or index literal of names", t.String())
+
+	decl, ok := findFuncDecl(files, fn.Pos())
+	if !ok || decl.Body == nil || decl.Recv == nil {
+		return nil, rerr
+	}
+	recv := decl.Recv.List[0]
+	if len(recv.Names) == 0 {
+		return nil, rerr
+	}
+	rname := recv.Names[0].Name
+	scope := t.Obj().Pkg().Scope()
+
+	for _, stmt := range decl.Body.List {
+		switch x := stmt.(type) {
+		case *ast.SwitchStmt:
+			if !isIdent(x.Tag, rname) {
+				continue
+			}
+			names, ok := evalSwitch(x, scope)
+			if ok {
+				return names, nil
+			}
+		case *ast.ReturnStmt:
+			if len(x.Results) != 1 {
+				continue
+			}
+			ix, ok := x.Results[0].(*ast.IndexExpr)
+			if !ok || !isIdent(ix.Index, rname) {
+				continue
+			}
+			id, ok := ix.X.(*ast.Ident)
+			if !ok {
+				continue
+			}
+			v, ok := scope.Lookup(id.Name).(*types.Var)
+			if !ok {
+				continue
+			}
+			lit, ok := findVarLit(files, v.Pos())
+			if !ok {
+				continue
+			}
+			names, ok := evalLit(lit, scope)
+			if ok {
+				return names, nil
+			}
+		}
+	}
+	return nil, rerr
+}
+
+func evalSwitch(x *ast.SwitchStmt, scope *types.Scope) (map[int64]string, bool) {
+	names := make(map[int64]string)
+	for _, s := range x.Body.List {
+		cc, ok := s.(*ast.CaseClause)
+		if !ok || len(cc.Body) == 0 {
+			continue
+		}
+		ret, ok := cc.Body[0].(*ast.ReturnStmt)
+		if !ok || len(ret.Results) != 1 {
+			continue
+		}
+		name, ok := evalString(ret.Results[0], scope)
+		if !ok {
+			continue
+		}
+		for _, e := range cc.List {
+			v, ok := evalInt(e, scope)
+			if !ok {
+				return nil, false
+			}
+			names[v] = name
+		}
+	}
+	return names, len(names) > 0
+}
+
+func evalLit(lit *ast.CompositeLit, scope *types.Scope) (map[int64]string, bool) {
+	names := make(map[int64]string)
+	var index int64
+	for _, e := range lit.Elts {
+		val := e
+		if kv, ok := e.(*ast.KeyValueExpr); ok {
+			k, ok := evalInt(kv.Key, scope)
+			if !ok {
+				return nil, false
+			}
+			index = k
+			val = kv.Value
+		}
+		name, ok := evalString(val, scope)
+		if !ok {
+			return nil, false
+		}
+		names[index] = name
+		index++
+	}
+	return names, len(names) > 0
+}
+
+// evalString evaluates string literal or slice of string
+// constant, ie. _T_name[0:5] generated by stringer.
+func evalString(e ast.Expr, scope *types.Scope) (string, bool) {
+	switch x := e.(type) {
+	case *ast.BasicLit:
+		if x.Kind != token.STRING {
+			return "", false
+		}
+		s, err := strconv.Unquote(x.Value)
+		return s, err == nil
+	case *ast.SliceExpr:
+		id, ok := x.X.(*ast.Ident)
+		if !ok || x.Low == nil || x.High == nil || x.Slice3 {
+			return "", false
+		}
+		c, ok := scope.Lookup(id.Name).(*types.Const)
+		if !ok || c.Val().Kind() != constant.String {
+			return "", false
+		}
+		s := constant.StringVal(c.Val())
+		lo, lok := evalInt(x.Low, scope)
+		hi, hok := evalInt(x.High, scope)
+		if !lok || !hok || lo < 0 || lo > hi || hi > int64(len(s)) {
+			return "", false
+		}
+		return s[lo:hi], true
+	}
+	return "", false
+}
+
+func evalInt(e ast.Expr, scope *types.Scope) (int64, bool) {
+	var val constant.Value
+	switch x := e.(type) {
+	case *ast.Ident:
+		c, ok := scope.Lookup(x.Name).(*types.Const)
+		if !ok {
+			return 0, false
+		}
+		val = c.Val()
+	case *ast.BasicLit:
+		val = constant.MakeFromLiteral(x.Value, x.Kind, 0)
+	default:
+		return 0, false
+	}
+	return constant.Int64Val(constant.ToInt(val))
+}
+
+func isIdent(e ast.Expr, name string) bool {
+	id, ok := e.(*ast.Ident)
+	return ok && id.Name == name
+}
+
+func findFuncDecl(files []*ast.File, pos token.Pos) (*ast.FuncDecl, bool) {
+	for _, f := range files {
+		for _, d := range f.Decls {
+			fd, ok := d.(*ast.FuncDecl)
+			if ok && fd.Name.Pos() == pos {
+				return fd, true
+			}
+		}
+	}
+	return nil, false
+}
+
+func findVarLit(files []*ast.File, pos token.Pos) (*ast.CompositeLit, bool) {
+	for _, f := range files {
+		for _, d := range f.Decls {
+			gd, ok := d.(*ast.GenDecl)
+			if !ok || gd.Tok != token.VAR {
+				continue
+			}
+			for _, s := range gd.Specs {
+				vs := s.(*ast.ValueSpec)
+				for i, n := range vs.Names {
+					if n.Pos() != pos || i >= len(vs.Values) {
+						continue
+					}
+					lit, ok := vs.Values[i].(*ast.CompositeLit)
+					return lit, ok
+				}
+			}
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,211 @@
+package types
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+)
+
+// typeName returns name of named type as used in pointers. Instantiated
+// generic types carry their type arguments, ie. Page[Item]. Type arguments
+// declared in other package are qualified by package name, ie. Page[items.Item].
+// Name contains no spaces, so it can be used as argument of commands.
+func typeName(t *types.Named) string {
+	if t.TypeArgs().Len() == 0 {
+		return t.Obj().Name()
+	}
+	pkg := t.Obj().Pkg()
+	name := types.TypeString(t, func(p *types.Package) string {
+		if p == pkg {
+			return ""
+		}
+		return p.Name()
+	})
+	return strings.ReplaceAll(name, ", ", ",")
+}
+
+// isGeneric reports if type has type parameters which were not instantiated.
+func isGeneric(t *types.Named) bool {
+	return t.TypeParams().Len() > 0 && t.TypeArgs().Len() == 0
+}
+
+// isInstance reports if pointer head refers to instantiated generic type.
+func isInstance(head string) bool {
+	return strings.Contains(head, "[")
+}
+
+// ComponentName converts name of type to name usable as component key,
+// type arguments of generic types are appended to name and package
+// qualifiers are dropped, ie. Page[items.Item] becomes PageItem.
+func ComponentName(name string) string {
+	ff := strings.FieldsFunc(name, func(r rune) bool {
+		return !(unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '.')
+	})
+	var b strings.Builder
+	for i, f := range ff {
+		if j := strings.LastIndex(f, "."); j >= 0 {
+			f = f[j+1:]
+		}
+		if i > 0 && len(f) > 0 {
+			f = strings.ToUpper(f[:1]) + f[1:]
+		}
+		b.WriteString(f)
+	}
+	return b.String()
+}
+
+// instantiate will parse head of pointer such as Page[Item] and
+// instantiate generic type declared in given package.
+func (r *Scanner) instantiate(pkg *types.Package, head string) (*types.Named, error) {
+	expr, err := parser.ParseExpr(head)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse type %q", head)
+	}
+	t, err := r.expr2type(pkg, expr)
+	if err != nil {
+		return nil, err
+	}
+	named, ok := t.(*types.Named)
+	if !ok || named.TypeArgs().Len() == 0 {
+		return nil, errors.Errorf("type %q is not instantiated generic type", head)
+	}
+	return named, nil
+}
+
+// expr2type converts type expression to type, identifiers are looked up
+// in scope of given package.
+func (r *Scanner) expr2type(pkg *types.Package, e ast.Expr) (types.Type, error) {
+	switch x := e.(type) {
+	case *ast.Ident:
+		obj := pkg.Scope().Lookup(x.Name)
+		if obj == nil {
+			obj = types.Universe.Lookup(x.Name)
+		}
+		tn, ok := obj.(*types.TypeName)
+		if !ok {
+			return nil, errors.Errorf("type %q not found in package %q", x.Name, pkg.Path())
+		}
+		return tn.Type(), nil
+
+	case *ast.SelectorExpr:
+		id, ok := x.X.(*ast.Ident)
+		if !ok {
+			return nil, errors.Errorf("invalid type qualifier %q", types.ExprString(x))
+		}
+		p, err := r.lookupPkg(id.Name)
+		if err != nil {
+			return nil, err
+		}
+		return r.expr2type(p, x.Sel)
+
+	case *ast.StarExpr:
+		t, err := r.expr2type(pkg, x.X)
+		if err != nil {
+			return nil, err
+		}
+		return types.NewPointer(t), nil
+
+	case *ast.ArrayType:
+		t, err := r.expr2type(pkg, x.Elt)
+		if err != nil {
+			return nil, err
+		}
+		if x.Len == nil {
+			return types.NewSlice(t), nil
+		}
+		lit, ok := x.Len.(*ast.BasicLit)
+		if !ok || lit.Kind != token.INT {
+			return nil, errors.Errorf("invalid array length %q", types.ExprString(x.Len))
+		}
+		n, err := strconv.ParseInt(lit.Value, 0, 64)
+		if err != nil {
+			return nil, err
+		}
+		return types.NewArray(t, n), nil
+
+	case *ast.MapType:
+		k, err := r.expr2type(pkg, x.Key)
+		if err != nil {
+			return nil, err
+		}
+		v, err := r.expr2type(pkg, x.Value)
+		if err != nil {
+			return nil, err
+		}
+		return types.NewMap(k, v), nil
+
+	case *ast.IndexExpr:
+		return r.instantiateExpr(pkg, x.X, x.Index)
+
+	case *ast.IndexListExpr:
+		return r.instantiateExpr(pkg, x.X, x.Indices...)
+
+	default:
+		return nil, errors.Errorf("unsupported type expression %q", types.ExprString(e))
+	}
+}
+
+func (r *Scanner) instantiateExpr(pkg *types.Package, base ast.Expr, args ...ast.Expr) (types.Type, error) {
+	t, err := r.expr2type(pkg, base)
+	if err != nil {
+		return nil, err
+	}
+	named, ok := t.(*types.Named)
+	if !ok || !isGeneric(named) {
+		return nil, errors.Errorf("type %q is not generic", t.String())
+	}
+	var targs []types.Type
+	for _, a := range args {
+		var ta types.Type
+		ta, err = r.expr2type(pkg, a)
+		if err != nil {
+			return nil, err
+		}
+		targs = append(targs, ta)
+	}
+	inst, err := types.Instantiate(nil, named, targs, true)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to instantiate %q", named.String())
+	}
+	return inst, nil
+}
+
+// addPkg will remember package and all packages it imports, those
+// are used to look up package qualifiers of type arguments.
+func (r *Scanner) addPkg(pkg *types.Package) {
+	if r.pkgs == nil {
+		r.pkgs = make(map[string]*types.Package)
+	}
+	if _, ok := r.pkgs[pkg.Path()]; ok {
+		return
+	}
+	r.pkgs[pkg.Path()] = pkg
+	for _, p := range pkg.Imports() {
+		r.addPkg(p)
+	}
+}
+
+// lookupPkg finds package by its name, name has to be unique
+// among known packages.
+func (r *Scanner) lookupPkg(name string) (*types.Package, error) {
+	var found *types.Package
+	for _, p := range r.pkgs {
+		if p.Name() != name {
+			continue
+		}
+		if found != nil {
+			return nil, errors.Errorf("package name %q is ambiguous (%q, %q)", name, found.Path(), p.Path())
+		}
+		found = p
+	}
+	if found == nil {
+		return nil, errors.Errorf("package %q not found", name)
+	}
+	return found, nil
+}
@@ -0,0 +1,48 @@
+package types
+
+import (
+	"go/token"
+	"go/types"
+)
+
+var (
+	errorType = types.Universe.Lookup("error").Type()
+	bytesType = types.NewSlice(types.Typ[types.Byte])
+
+	// jsonMarshaler is encoding/json.Marshaler interface
+	jsonMarshaler = newMarshalerInterface("MarshalJSON")
+
+	// textMarshaler is encoding.TextMarshaler interface
+	textMarshaler = newMarshalerInterface("MarshalText")
+)
+
+// newMarshalerInterface creates interface with single method
+// of signature func() ([]byte, error)
+func newMarshalerInterface(method string) *types.Interface {
+	res := types.NewTuple(
+		types.NewVar(token.NoPos, nil, "", bytesType),
+		types.NewVar(token.NoPos, nil, "", errorType),
+	)
+	sig := types.NewSignature(nil, nil, res, false)
+	fn := types.NewFunc(token.NoPos, nil, method, sig)
+	return types.NewInterfaceType([]*types.Func{fn}, nil).Complete()
+}
+
+// implements reports if type or pointer to type implements interface,
+// same as encoding/json does for addressable values.
+func implements(t types.Type, iface *types.Interface) bool {
+	if types.IsInterface(t) {
+		return false
+	}
+	return types.Implements(t, iface) || types.Implements(types.NewPointer(t), iface)
+}
+
+// isJSONMarshaler reports if type implements json.Marshaler
+func isJSONMarshaler(t types.Type) bool {
+	return implements(t, jsonMarshaler)
+}
+
+// isTextMarshaler reports if type implements encoding.TextMarshaler
+func isTextMarshaler(t types.Type) bool {
+	return implements(t, textMarshaler)
+}
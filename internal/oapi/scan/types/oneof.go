@@ -0,0 +1,133 @@
+package types
+
+import (
+	"go/types"
+	"sort"
+
+	"github.com/buypal/oapi-go/internal/oapi/resolver"
+	"github.com/buypal/oapi-go/pointer"
+	"github.com/buypal/oapi-go/spec"
+	"github.com/buypal/oapi-go/tag"
+	"github.com/pkg/errors"
+)
+
+// OneOf describes polymorphic interface, value of such interface
+// is one of its variants (implementations).
+type OneOf struct {
+	// Ptr is pointer to interface.
+	Ptr pointer.Pointer
+	// Discriminator is name of property holding name of variant,
+	// it is optional.
+	Discriminator string
+	// Variants of interface, if there are none they will be
+	// discovered in scanned packages.
+	Variants []Variant
+}
+
+// Variant is single implementation of polymorphic interface.
+type Variant struct {
+	Ptr pointer.Pointer
+	// Value of discriminator, if empty type name is used.
+	Value string
+}
+
+// Name returns name of variant type
+func (v Variant) Name() string {
+	name, _ := v.Ptr.Fragment.Last()
+	return name
+}
+
+func (v Variant) value() string {
+	if len(v.Value) > 0 {
+		return v.Value
+	}
+	return v.Name()
+}
+
+// AddOneOf registers polymorphic interface.
+func (r *Scanner) AddOneOf(o OneOf) {
+	if r.points.oneofs == nil {
+		r.points.oneofs = make(map[string]*OneOf)
+	}
+	r.points.oneofs[o.Ptr.String()] = &o
+}
+
+// ExportedComponents will discover variants of polymorphic interfaces
+// which were not listed explicitly. Discovered variants are exported
+// as schemas, so discriminator can map to them.
+func (r *Scanner) ExportedComponents() (exports resolver.Exports, err error) {
+	for _, o := range r.points.oneofs {
+		if len(o.Variants) > 0 {
+			continue
+		}
+		obj, ok := r.points.object(o.Ptr)
+		if !ok {
+			return nil, errors.Errorf("failed to find interface %q", o.Ptr.String())
+		}
+		iface, ok := obj.Type().Underlying().(*types.Interface)
+		if !ok {
+			return nil, errors.Errorf("type %q is not an interface", obj.Type().String())
+		}
+		for _, c := range r.candidates {
+			if c == obj || types.IsInterface(c.Type()) || !implements(c.Type(), iface) {
+				continue
+			}
+			err = collectTypes(c, &r.points)
+			if err != nil {
+				return
+			}
+			p := newPoint(c)
+			o.Variants = append(o.Variants, Variant{Ptr: p.Pointer})
+			if _, ok := exports.Get(p.Pointer); ok {
+				continue
+			}
+			exports = append(exports, resolver.Pointer{
+				Pointer: p.Pointer,
+				Entity: resolver.Entity{
+					Entity: spec.SchemaKind,
+					Name:   c.Name(),
+				},
+			})
+			r.AddExport(p.Pointer)
+		}
+		if len(o.Variants) == 0 {
+			return nil, errors.Errorf("no implementation of interface %q found", obj.Type().String())
+		}
+		sort.Slice(o.Variants, func(i, j int) bool {
+			return o.Variants[i].Ptr.String() < o.Variants[j].Ptr.String()
+		})
+	}
+	return
+}
+
+// oneof2schema will convert polymorphic interface to oneOf schema,
+// listed variants have to implement the interface.
+func oneof2schema(o *OneOf, t *types.Named, m pointmap, tg tag.Tag) (s *spec.Schema, err error) {
+	if len(o.Variants) == 0 {
+		return nil, errors.Errorf("interface %q has no variants", o.Ptr.String())
+	}
+	iface := t.Underlying().(*types.Interface)
+	s = &spec.Schema{}
+	for _, v := range o.Variants {
+		if obj, ok := m.object(v.Ptr); ok && !implements(obj.Type(), iface) {
+			return nil, errors.Errorf("variant %q does not implement interface %q", v.Ptr.String(), t.String())
+		}
+		ptr := v.Ptr.Clone()
+		x := &spec.Schema{}
+		x.Ref = &ptr
+		s.OneOf = append(s.OneOf, x)
+	}
+	if len(o.Discriminator) > 0 {
+		s.Discriminator = &spec.Discriminator{
+			PropertyName: o.Discriminator,
+			Mapping:      make(map[string]string),
+		}
+		for _, v := range o.Variants {
+			s.Discriminator.Mapping[v.value()] = v.Ptr.String()
+		}
+	}
+	if tg.Nullable != nil {
+		s.Nullable = *tg.Nullable
+	}
+	return
+}
@@ -1,12 +1,14 @@
 package types
 
 import (
+	"go/ast"
+	"go/token"
 	"go/types"
 	"sort"
 	"strings"
 
 	"github.com/buypal/oapi-go/internal/logging"
-	"github.com/buypal/oapi-go/internal/pointer"
+	"github.com/buypal/oapi-go/pointer"
 	"golang.org/x/tools/go/types/typeutil"
 )
 
@@ -20,6 +22,13 @@ func newPoint(root types.Object) point {
 	return point{Pointer: ptr}
 }
 
+// namedPoint creates point of named type, instantiated generic
+// types are pointed to with their type arguments, ie. Page[Item].
+func namedPoint(t *types.Named) point {
+	ptr, _ := pointer.NewGoPointer(t.Obj().Pkg().Path(), typeName(t))
+	return point{Pointer: ptr}
+}
+
 func withDescendant(ptr point, name string) point {
 	x := ptr.Clone()
 	fx, _ := x.Fragment.Descendant(name)
@@ -92,7 +101,70 @@ func (s points) String() string {
 }
 
 type pointmap struct {
-	m typeutil.Map
+	m        typeutil.Map
+	objs     map[string]types.Object
+	insts    map[string]*types.Named
+	docs     docs
+	files    map[string][]*ast.File
+	mode     Mode
+	oneofs   map[string]*OneOf
+	exports  map[string]bool
+	registry registry
+	fset     *token.FileSet
+}
+
+// position returns position of object in go file
+func (tp pointmap) position(obj types.Object) token.Position {
+	if tp.fset == nil || obj == nil {
+		return token.Position{}
+	}
+	return tp.fset.Position(obj.Pos())
+}
+
+// setObject will remember object (type name or field) standing behind
+// given point.
+func (tp *pointmap) setObject(p point, obj types.Object) {
+	if tp.objs == nil {
+		tp.objs = make(map[string]types.Object)
+	}
+	if _, ok := tp.objs[p.String()]; ok {
+		return
+	}
+	tp.objs[p.String()] = obj
+}
+
+// object returns object standing behind given pointer.
+func (tp pointmap) object(p pointer.Pointer) (types.Object, bool) {
+	obj, ok := tp.objs[p.String()]
+	return obj, ok
+}
+
+// setInstance will remember instantiated generic type standing
+// behind given point. Object of such type is its generic origin.
+func (tp *pointmap) setInstance(p point, t *types.Named) {
+	if tp.insts == nil {
+		tp.insts = make(map[string]*types.Named)
+	}
+	tp.insts[p.String()] = t
+}
+
+// instance returns instantiated generic type standing behind given pointer.
+func (tp pointmap) instance(p pointer.Pointer) (*types.Named, bool) {
+	t, ok := tp.insts[p.String()]
+	return t, ok
+}
+
+// addFiles will remember syntax of given package and collect
+// doc comments from its files.
+func (tp *pointmap) addFiles(pkgPath string, files ...*ast.File) {
+	if tp.docs == nil {
+		tp.docs = make(docs)
+	}
+	if tp.files == nil {
+		tp.files = make(map[string][]*ast.File)
+	}
+	tp.files[pkgPath] = append(tp.files[pkgPath], files...)
+	tp.docs.add(files...)
 }
 
 func (tp pointmap) len(t types.Type, r point) bool {
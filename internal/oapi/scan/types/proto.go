@@ -0,0 +1,202 @@
+package types
+
+import (
+	"go/types"
+	"sort"
+	"strings"
+
+	"github.com/buypal/oapi-go/spec"
+	"github.com/buypal/oapi-go/tag"
+	"github.com/pkg/errors"
+)
+
+const wktPkg = "google.golang.org/protobuf/types/known/"
+
+// protoRegistry holds canonical protojson schemas of well known types.
+var protoRegistry = map[string]spec.Schema{
+	wktPkg + "timestamppb.Timestamp": *spec.StrFmtProperty("date-time"),
+	wktPkg + "durationpb.Duration": {
+		Type:    spec.TypeString,
+		Pattern: `^-?[0-9]+(\.[0-9]+)?s$`,
+	},
+	wktPkg + "structpb.Struct": {
+		Type:                 spec.TypeObject,
+		AdditionalProperties: &spec.Schema{},
+	},
+	wktPkg + "structpb.Value": {},
+	wktPkg + "structpb.ListValue": {
+		Type:  spec.TypeArray,
+		Items: &spec.Schema{},
+	},
+	wktPkg + "emptypb.Empty":          {Type: spec.TypeObject},
+	wktPkg + "fieldmaskpb.FieldMask":  {Type: spec.TypeString},
+	wktPkg + "wrapperspb.StringValue": *spec.StringProperty(),
+	wktPkg + "wrapperspb.BytesValue":  *spec.ByteProperty(),
+	wktPkg + "wrapperspb.BoolValue":   *spec.BooleanProperty(),
+	wktPkg + "wrapperspb.Int32Value":  *spec.Int32Property(),
+	wktPkg + "wrapperspb.UInt32Value": basicSchema(types.Uint32),
+	wktPkg + "wrapperspb.FloatValue":  *spec.Float32Property(),
+	wktPkg + "wrapperspb.DoubleValue": *spec.Float64Property(),
+	wktPkg + "wrapperspb.Int64Value":  *quoted2schema(types.Int64, spec.Int64Property(), tag.Tag{}),
+	wktPkg + "wrapperspb.UInt64Value": *quoted2schema(types.Uint64, spec.Int64Property(), tag.Tag{}),
+	wktPkg + "anypb.Any": {
+		Type: spec.TypeObject,
+		Properties: map[string]*spec.Schema{
+			"@type": spec.StringProperty(),
+		},
+		Required: []string{"@type"},
+	},
+}
+
+// basicSchema describes basic type same as fields of that type are described
+func basicSchema(t types.BasicKind) spec.Schema {
+	s, err := basic2schema(t, tag.Tag{})
+	if err != nil {
+		panic(err)
+	}
+	return *s
+}
+
+// isProtoMessage reports if struct was generated by protoc-gen-go,
+// that is some of its fields carry protobuf tag.
+func isProtoMessage(fields []structField) bool {
+	for _, x := range fields {
+		if x.tag.Proto != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// isProtoField reports if field is part of protojson representation,
+// fields of old generators prefixed by XXX_ are internal.
+func isProtoField(x structField) bool {
+	return x.tag.Proto != nil && !strings.HasPrefix(x.field.Name(), "XXX_")
+}
+
+// protoOneofVariants will find wrappers of oneof fields implementing
+// oneof interface, they are declared in same package as interface.
+func protoOneofVariants(iface *types.Named) (vv []*types.Named) {
+	it, ok := iface.Underlying().(*types.Interface)
+	if !ok || iface.Obj().Pkg() == nil {
+		return
+	}
+	scope := iface.Obj().Pkg().Scope()
+	for _, name := range scope.Names() {
+		obj, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok || obj.IsAlias() {
+			continue
+		}
+		named, ok := obj.Type().(*types.Named)
+		if !ok || types.IsInterface(named) || !implements(named, it) {
+			continue
+		}
+		st, ok := named.Underlying().(*types.Struct)
+		if !ok || st.NumFields() != 1 {
+			continue
+		}
+		tg, err := tag.Parse(st.Tag(0))
+		if err != nil || tg.Proto == nil || !tg.Proto.Oneof {
+			continue
+		}
+		vv = append(vv, named)
+	}
+	// keep order of declaration
+	sort.SliceStable(vv, func(i, j int) bool {
+		return vv[i].Obj().Pos() < vv[j].Obj().Pos()
+	})
+	return
+}
+
+// protoOneof2schema describes oneof field, protojson inlines fields of oneof
+// into message, so each wrapper is object with single required property.
+func protoOneof2schema(f *types.Var, m pointmap, tp path) (s *spec.Schema, err error) {
+	named, ok := types.Unalias(f.Type()).(*types.Named)
+	if !ok {
+		return nil, errors.Errorf("oneof field %q is not an interface", f.Name())
+	}
+	vv := protoOneofVariants(named)
+	if len(vv) == 0 {
+		return nil, errors.Errorf("no wrappers of oneof field %q found", f.Name())
+	}
+	s = &spec.Schema{}
+	for _, v := range vv {
+		st := v.Underlying().(*types.Struct)
+		tg, _ := tag.Parse(st.Tag(0))
+		var ps *spec.Schema
+		ps, err = type2schema(st.Field(0).Type(), m, tp, tg)
+		if err != nil {
+			return
+		}
+		protoQuote(ps, st.Field(0).Type())
+		name := tg.Proto.JSON()
+		s.OneOf = append(s.OneOf, &spec.Schema{
+			Type:       spec.TypeObject,
+			Properties: map[string]*spec.Schema{name: ps},
+			Required:   []string{name},
+		})
+	}
+	return
+}
+
+// protoEnum2schema describes protobuf enum, protojson encodes enums by
+// their names. Names are taken from <Enum>_name map generated for enum.
+func protoEnum2schema(t *types.Named, m pointmap, tg tag.Tag) (*spec.Schema, bool, error) {
+	pkg := t.Obj().Pkg()
+	if pkg == nil {
+		return nil, false, nil
+	}
+	v, ok := pkg.Scope().Lookup(t.Obj().Name() + "_name").(*types.Var)
+	if !ok {
+		return nil, false, nil
+	}
+	lit, ok := findVarLit(m.files[pkg.Path()], v.Pos())
+	if !ok {
+		return nil, false, nil
+	}
+	names, ok := evalLit(lit, pkg.Scope())
+	if !ok {
+		err := errors.Errorf("failed to evaluate names of enum %q", t.String())
+		return nil, true, err
+	}
+	var keys []int64
+	for k := range names {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	s, err := basic2schema(types.String, tg)
+	if err != nil {
+		return nil, true, err
+	}
+	for _, k := range keys {
+		s.Enum = append(s.Enum, spec.NewAny(names[k]))
+	}
+	return s, true, nil
+}
+
+// protoQuote will describe 64 bit integers as strings,
+// protojson encodes them as strings same as ",string" option does.
+// Kind of integer is taken from go type t the schema describes.
+func protoQuote(s *spec.Schema, t types.Type) {
+	if s == nil || s.Ref != nil {
+		return
+	}
+	switch u := t.Underlying().(type) {
+	case *types.Pointer:
+		protoQuote(s, u.Elem())
+	case *types.Slice:
+		protoQuote(s.Items, u.Elem())
+	case *types.Map:
+		protoQuote(s.AdditionalProperties, u.Elem())
+	case *types.Basic:
+		kind := u.Kind()
+		if s.Type != spec.TypeInteger || (kind != types.Int64 && kind != types.Uint64) {
+			return
+		}
+		q := quoted2schema(kind, s, tag.Tag{})
+		q.Nullable = s.Nullable
+		q.Description = s.Description
+		*s = *q
+	}
+}
@@ -0,0 +1,146 @@
+package types
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/buypal/oapi-go/pointer"
+	"github.com/buypal/oapi-go/spec"
+	"github.com/pkg/errors"
+)
+
+// registry maps pointers of well known types to their schemas encoded
+// as json, so every use gets its own copy. These types are not scanned,
+// schema from registry is used instead.
+type registry map[string][]byte
+
+// stdRegistry holds built-in schemas of standard library
+// and other commonly used types, keys are go type names.
+var stdRegistry = map[string]spec.Schema{
+	"time.Time": *spec.StrFmtProperty("date-time"),
+	"time.Duration": {
+		Type:        spec.TypeInteger,
+		Format:      "int64",
+		Description: "Duration in nanoseconds.",
+	},
+	"net.IP":                   ipSchema(),
+	"net/netip.Addr":           ipSchema(),
+	"net/url.URL":              *spec.StrFmtProperty("uri"),
+	"encoding/json.RawMessage": {},
+	// json.RawMessage is alias of jsontext.Value with json/v2
+	"encoding/json/jsontext.Value": {},
+	"encoding/json.Number":         {Type: spec.TypeNumber},
+	"math/big.Int":                 {Type: spec.TypeInteger},
+	"database/sql.NullString":      nullSchema("String", spec.StringProperty()),
+	"database/sql.NullInt64":       nullSchema("Int64", spec.Int64Property()),
+	"database/sql.NullInt32":       nullSchema("Int32", spec.Int32Property()),
+	"database/sql.NullInt16":       nullSchema("Int16", spec.Int32Property()),
+	"database/sql.NullByte":        nullSchema("Byte", spec.Int32Property()),
+	"database/sql.NullFloat64":     nullSchema("Float64", spec.Float64Property()),
+	"database/sql.NullBool":        nullSchema("Bool", spec.BooleanProperty()),
+	"database/sql.NullTime":        nullSchema("Time", spec.StrFmtProperty("date-time")),
+	"github.com/google/uuid.UUID":  *spec.StrFmtProperty("uuid"),
+}
+
+// ipSchema describes textual form of IP address
+func ipSchema() spec.Schema {
+	return spec.Schema{
+		Type: spec.TypeString,
+		AnyOf: []*spec.Schema{
+			spec.StrFmtProperty("ipv4"),
+			spec.StrFmtProperty("ipv6"),
+		},
+	}
+}
+
+// nullSchema describes sql.Null* types, those do not implement
+// json.Marshaler, so they are encoded as structs.
+func nullSchema(field string, s *spec.Schema) spec.Schema {
+	return spec.Schema{
+		Type: spec.TypeObject,
+		Properties: map[string]*spec.Schema{
+			field:   s,
+			"Valid": spec.BooleanProperty(),
+		},
+		Required: []string{field, "Valid"},
+	}
+}
+
+// builtins is registry of built-in schemas
+var builtins = func() registry {
+	r := make(registry)
+	for name, s := range stdRegistry {
+		if err := r.add(name, s); err != nil {
+			panic(err)
+		}
+	}
+	return r
+}()
+
+// newRegistry creates registry with built-in schemas, protobuf
+// well known types are registered only in ProtoJSON mode.
+func newRegistry(mode Mode) registry {
+	r := make(registry)
+	for k, s := range builtins {
+		r[k] = s
+	}
+	if mode&ProtoJSON == 0 {
+		return r
+	}
+	for name, s := range protoRegistry {
+		if err := r.add(name, s); err != nil {
+			panic(err)
+		}
+	}
+	return r
+}
+
+// add will register schema of type given by its go type name,
+// ie. time.Time or github.com/google/uuid.UUID.
+func (r registry) add(name string, s spec.Schema) error {
+	ptr, err := typePointer(name)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		return errors.Wrapf(err, "invalid schema of type %q", name)
+	}
+	r[ptr.String()] = data
+	return nil
+}
+
+// get returns copy of schema of type behind pointer, registry
+// which was not created by newRegistry holds only built-in schemas.
+func (r registry) get(ptr pointer.Pointer) (*spec.Schema, bool) {
+	if r == nil {
+		r = builtins
+	}
+	data, ok := r[ptr.String()]
+	if !ok {
+		return nil, false
+	}
+	s := &spec.Schema{}
+	// schemas were encoded by add, so they are always valid
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, false
+	}
+	return s, true
+}
+
+// typePointer converts go type name (import path and type name
+// separated by dot) to pointer.
+func typePointer(name string) (pointer.Pointer, error) {
+	i := strings.LastIndex(name, ".")
+	if i <= strings.LastIndex(name, "/") || i == len(name)-1 {
+		return pointer.Pointer{}, errors.Errorf("invalid go type name %q", name)
+	}
+	return pointer.NewGoPointer(name[:i], name[i+1:])
+}
+
+// AddType will register schema of type given by its go type name,
+// ie. github.com/google/uuid.UUID. Registered schemas are used
+// instead of scanning given type.
+func (r *Scanner) AddType(name string, s spec.Schema) error {
+	return r.points.registry.add(name, s)
+}
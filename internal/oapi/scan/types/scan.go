@@ -1,36 +1,131 @@
 package types
 
 import (
+	"go/types"
+
+	"github.com/buypal/oapi-go/internal/diag"
 	"github.com/buypal/oapi-go/internal/logging"
-	"github.com/buypal/oapi-go/internal/oapi/spec"
-	"github.com/buypal/oapi-go/internal/pointer"
+	"github.com/buypal/oapi-go/pointer"
+	"github.com/buypal/oapi-go/spec"
 	"github.com/buypal/oapi-go/tag"
 	"github.com/pkg/errors"
 	"golang.org/x/tools/go/packages"
 )
 
+// Mode alters how types are converted into schemas.
+type Mode uint
+
+const (
+	// EnumStringer will use String() method of integer enums
+	// as enum values instead of integers.
+	EnumStringer Mode = 1 << iota
+	// InferRequired will make fields which are neither omitempty
+	// nor pointers required.
+	InferRequired
+	// ComposeEmbedded will describe structs embedding exported
+	// types as allOf composition instead of inlining their fields.
+	ComposeEmbedded
+	// ProtoJSON will describe types generated by protoc-gen-go
+	// as they are encoded by protojson.
+	ProtoJSON
+)
+
 // Scanner will scan types and allow to resolve tem into full structures
 type Scanner struct {
-	Pointers pointer.Pointers
-	points   pointmap
+	Pointers   pointer.Pointers
+	points     pointmap
+	candidates []types.Object
+	pkgs       map[string]*types.Package
+	pending    map[string]error
+	extensions map[string]Extension
+}
+
+// Extension holds specification extensions of type,
+// values are json or plain strings, ie. x-go-name=Foo.
+type Extension struct {
+	Ptr    pointer.Pointer
+	Values map[string]string
 }
 
-func NewScanner(ptrs pointer.Pointers) *Scanner {
+func NewScanner(ptrs pointer.Pointers, mode Mode) *Scanner {
 	return &Scanner{
 		Pointers: ptrs,
+		points:   pointmap{mode: mode, registry: newRegistry(mode)},
+	}
+}
+
+// AddExport registers pointer which is exported as component.
+func (r *Scanner) AddExport(ptr pointer.Pointer) {
+	if r.points.exports == nil {
+		r.points.exports = make(map[string]bool)
+	}
+	r.points.exports[ptr.String()] = true
+}
+
+// AddExtension registers extensions of type.
+func (r *Scanner) AddExtension(e Extension) {
+	if r.extensions == nil {
+		r.extensions = make(map[string]Extension)
 	}
+	r.extensions[e.Ptr.String()] = e
 }
 
 // Resolve will return new pointer and scheme, new pointer might be returned in cases
 // where original pointer is not fully resolved.
 func (r *Scanner) Resolve(ptr pointer.Pointer) (*spec.Schema, error) {
 	tp, _ := r.points.findType(ptr)
+	obj, ok := r.points.object(ptr)
+	if ok {
+		tp = obj.Type()
+	}
+	if inst, ok := r.points.instance(ptr); ok {
+		tp = inst
+	}
+	if tp == nil {
+		head, _ := ptr.Fragment.Head()
+		hp, _ := pointer.NewGoPointer(ptr.PkgPath(), head)
+		if err, ok := r.pending[hp.String()]; ok {
+			return nil, err
+		}
+		return nil, errors.Errorf("failed to resolve %q", ptr.String())
+	}
 	// pp, ok := r.points.pick(tp)
 	// if !ok {
 	// 	return nil, errors.Errorf("failed to resolve %q", ptr.String())
 	// }
 	sch, err := type2schema(tp, r.points, path{}, tag.Tag{})
-	return sch, err
+	if err != nil {
+		return nil, diag.At(r.points.position(obj), errors.Wrapf(err, "type %q", ptr.String()))
+	}
+	describe(sch, r.points, ptr)
+	if e, ok := r.extensions[ptr.String()]; ok {
+		// siblings of $ref are ignored, extensions are set on wrapper
+		if sch.Ref != nil {
+			sch = &spec.Schema{AllOf: []*spec.Schema{sch}}
+		}
+		sch.Extensions = extensions(sch.Extensions, e.Values)
+	}
+	return sch, nil
+}
+
+// describe will use doc comment of object behind pointer as
+// description of schema. Named types also receive title.
+func describe(s *spec.Schema, m pointmap, ptr pointer.Pointer) {
+	if s.Ref != nil {
+		return
+	}
+	obj, ok := m.object(ptr)
+	if !ok {
+		return
+	}
+	doc, ok := m.docs.of(obj)
+	if !ok {
+		return
+	}
+	if _, ok := obj.(*types.TypeName); ok {
+		s.Title = synopsis(doc)
+	}
+	s.Description = doc
 }
 
 func (r *Scanner) log(log logging.Printer) {
@@ -40,28 +135,81 @@ func (r *Scanner) log(log logging.Printer) {
 // Scan will scan types in pkgs
 func (r *Scanner) Scan(pkg *packages.Package) (errs error) {
 	scope := pkg.Types.Scope()
+	r.points.fset = pkg.Fset
+	r.points.addFiles(pkg.Types.Path(), pkg.Syntax...)
+	r.collectCandidates(scope)
+	r.addPkg(pkg.Types)
 
 	for _, ptr := range r.Pointers {
 		url := ptr.URL
 		if url.Scheme != "go" {
 			continue
 		}
-		if pkg.Types.Path() != ptr.PkgPath() {
-			continue
-		}
 		head, ok := ptr.Fragment.Head()
 		if !ok {
 			continue
 		}
+		if isInstance(head) {
+			r.collectInstance(ptr, head)
+			continue
+		}
+		if pkg.Types.Path() != ptr.PkgPath() {
+			continue
+		}
 		obj := scope.Lookup(head)
 		if obj == nil {
 			continue
 		}
 		err := collectTypes(obj, &r.points)
 		if err != nil {
-			return errors.Wrapf(err, "failed to register type %q", obj.Type().String())
+			return diag.At(r.points.position(obj), errors.Wrapf(err, "failed to register type %q", obj.Type().String()))
 		}
 	}
 
 	return
 }
+
+// collectCandidates will remember exported types of package, these
+// might be variants of polymorphic interfaces without listed variants.
+func (r *Scanner) collectCandidates(scope *types.Scope) {
+	discover := false
+	for _, o := range r.points.oneofs {
+		discover = discover || len(o.Variants) == 0
+	}
+	if !discover {
+		return
+	}
+	for _, name := range scope.Names() {
+		obj, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok || !obj.Exported() || obj.IsAlias() {
+			continue
+		}
+		r.candidates = append(r.candidates, obj)
+	}
+}
+
+// collectInstance will instantiate generic type pointer is referring to.
+// Type arguments might be declared in packages which are not scanned yet,
+// so failed attempts are retried with every scanned package.
+func (r *Scanner) collectInstance(ptr pointer.Pointer, head string) {
+	pkg, ok := r.pkgs[ptr.PkgPath()]
+	if !ok {
+		return
+	}
+	p, _ := pointer.NewGoPointer(ptr.PkgPath(), head)
+	if _, ok := r.points.instance(p); ok {
+		return
+	}
+	if r.pending == nil {
+		r.pending = make(map[string]error)
+	}
+	named, err := r.instantiate(pkg, head)
+	if err == nil {
+		err = collectInstance(named, &r.points)
+	}
+	if err != nil {
+		r.pending[p.String()] = errors.Wrapf(err, "failed to register type %q", head)
+		return
+	}
+	delete(r.pending, p.String())
+}
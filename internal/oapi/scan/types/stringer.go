@@ -0,0 +1,124 @@
+package types
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"sort"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// stringerTables decodes names of constants from tables generated by
+// stringer tool, reports false if there are no such tables. Tool keeps
+// names of consecutive values (runs) in _T_name or _T_name_<run> constants
+// split by _T_index or _T_index_<run> arrays, sparse values are kept in
+// _T_map literal.
+func stringerTables(t *types.Named, files []*ast.File, consts []*types.Const) (map[int64]string, bool, error) {
+	scope := t.Obj().Pkg().Scope()
+	prefix := "_" + t.Obj().Name()
+	rerr := errors.Errorf("tables of stringer of %q do not match its constants, run go generate", t.String())
+
+	if v, ok := scope.Lookup(prefix + "_map").(*types.Var); ok {
+		lit, ok := findVarLit(files, v.Pos())
+		if !ok {
+			return nil, true, rerr
+		}
+		names, ok := evalLit(lit, scope)
+		if !ok {
+			return nil, true, rerr
+		}
+		return names, true, nil
+	}
+
+	// single run has no suffix
+	suffixes := []string{""}
+	if _, ok := scope.Lookup(prefix + "_name").(*types.Const); !ok {
+		suffixes = nil
+		for i := 0; scope.Lookup(prefix+"_name_"+strconv.Itoa(i)) != nil; i++ {
+			suffixes = append(suffixes, "_"+strconv.Itoa(i))
+		}
+	}
+	if len(suffixes) == 0 {
+		return nil, false, nil
+	}
+	var runs [][]string
+	for _, suffix := range suffixes {
+		run, ok := stringerRun(scope, files, prefix+"_name"+suffix, prefix+"_index"+suffix)
+		if !ok {
+			return nil, true, rerr
+		}
+		runs = append(runs, run)
+	}
+
+	// runs are made of consecutive distinct values of constants
+	groups := consecutive(consts)
+	if len(groups) != len(runs) {
+		return nil, true, rerr
+	}
+	names := make(map[int64]string)
+	for i, g := range groups {
+		if len(g) != len(runs[i]) {
+			return nil, true, rerr
+		}
+		for j, v := range g {
+			names[v] = runs[i][j]
+		}
+	}
+	return names, true, nil
+}
+
+// stringerRun splits names of run by index, run without
+// index array has single name.
+func stringerRun(scope *types.Scope, files []*ast.File, name, index string) ([]string, bool) {
+	c, ok := scope.Lookup(name).(*types.Const)
+	if !ok || c.Val().Kind() != constant.String {
+		return nil, false
+	}
+	s := constant.StringVal(c.Val())
+
+	v, ok := scope.Lookup(index).(*types.Var)
+	if !ok {
+		return []string{s}, true
+	}
+	lit, ok := findVarLit(files, v.Pos())
+	if !ok || len(lit.Elts) < 2 {
+		return nil, false
+	}
+	var bounds []int64
+	for _, e := range lit.Elts {
+		b, ok := evalInt(e, scope)
+		if !ok || b > int64(len(s)) || (len(bounds) > 0 && b < bounds[len(bounds)-1]) {
+			return nil, false
+		}
+		bounds = append(bounds, b)
+	}
+	var run []string
+	for i := 1; i < len(bounds); i++ {
+		run = append(run, s[bounds[i-1]:bounds[i]])
+	}
+	return run, true
+}
+
+// consecutive groups sorted distinct values of constants into
+// runs of consecutive values.
+func consecutive(consts []*types.Const) (groups [][]int64) {
+	seen := make(map[int64]bool)
+	var values []int64
+	for _, c := range consts {
+		v, _ := constant.Int64Val(c.Val())
+		if !seen[v] {
+			seen[v] = true
+			values = append(values, v)
+		}
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+	for i, v := range values {
+		if i == 0 || v != values[i-1]+1 {
+			groups = append(groups, nil)
+		}
+		groups[len(groups)-1] = append(groups[len(groups)-1], v)
+	}
+	return
+}
@@ -1,11 +1,12 @@
 package types
 
 import (
+	"go/constant"
 	"go/types"
 	"strings"
 
-	"github.com/buypal/oapi-go/internal/oapi/spec"
-	"github.com/buypal/oapi-go/internal/pointer"
+	"github.com/buypal/oapi-go/internal/diag"
+	"github.com/buypal/oapi-go/spec"
 	"github.com/buypal/oapi-go/tag"
 
 	"github.com/pkg/errors"
@@ -13,7 +14,17 @@ import (
 
 // collect types will wrap walk providing inital arguments
 func collectTypes(t types.Object, m *pointmap) error {
-	return walk(newPoint(t), t.Type(), path{}, m)
+	r := newPoint(t)
+	m.setObject(r, t)
+	return walk(r, t.Type(), path{}, m)
+}
+
+// collectInstance is same as collectTypes but for instantiated generic types
+func collectInstance(t *types.Named, m *pointmap) error {
+	r := namedPoint(t)
+	m.setObject(r, t.Obj())
+	m.setInstance(r, t)
+	return walk(r, t, path{}, m)
 }
 
 // elementer represents types such as slice, array, map or pointer
@@ -60,10 +71,25 @@ func walk(r point, t types.Type, p path, m *pointmap) (err error) {
 				continue
 			}
 
+			fp := withDescendant(r, field.Name())
+			m.setObject(fp, field)
+
 			next = append(next, tptr{
-				p: withDescendant(r, field.Name()),
+				p: fp,
 				t: field.Type(),
 			})
+
+			// wrappers of oneof fields are not reachable from message
+			named, ok := types.Unalias(field.Type()).(*types.Named)
+			if !ok || m.mode&ProtoJSON == 0 {
+				continue
+			}
+			for _, v := range protoOneofVariants(named) {
+				next = append(next, tptr{
+					p: namedPoint(v),
+					t: v,
+				})
+			}
 		}
 
 	case *types.Pointer:
@@ -84,9 +110,13 @@ func walk(r point, t types.Type, p path, m *pointmap) (err error) {
 		if err != nil {
 			return
 		}
-		named, ok := e.t.(*types.Named)
+		named, ok := types.Unalias(e.t).(*types.Named)
 		if ok {
-			np := newPoint(named.Obj())
+			np := namedPoint(named)
+			m.setObject(np, named.Obj())
+			if named.TypeArgs().Len() > 0 {
+				m.setInstance(np, named)
+			}
 			err = walk(np, e.t, p, m)
 		}
 		if err != nil {
@@ -131,6 +161,14 @@ func (tp path) visit(t types.Type) (p path, visited bool) {
 
 // type2schema will conver type to spec.Scheme
 func type2schema(t types.Type, m pointmap, tp path, tg tag.Tag) (*spec.Schema, error) {
+	t = types.Unalias(t)
+	if named, ok := t.(*types.Named); ok {
+		s, ok, err := named2schema(named, m, tp, tg)
+		if ok || err != nil {
+			return s, err
+		}
+	}
+
 	t = t.Underlying()
 
 	if tp.has(t) {
@@ -170,6 +208,61 @@ func type2schema(t types.Type, m pointmap, tp path, tg tag.Tag) (*spec.Schema, e
 	}
 }
 
+// named2schema handles named types which are not described by their
+// underlying type, reports false if type should be handled as usual.
+func named2schema(t *types.Named, m pointmap, tp path, tg tag.Tag) (*spec.Schema, bool, error) {
+	if isGeneric(t) {
+		err := errors.Errorf("generic type %q has to be instantiated", t.String())
+		return nil, true, err
+	}
+
+	ptr := namedPoint(t)
+	if sch, ok := m.registry.get(ptr.Pointer); ok {
+		return sch, true, nil
+	}
+
+	switch {
+	// Marshalers are referenced so resolver can look up overrides
+	// before the type is resolved on its own.
+	case len(tp) > 0 && (isJSONMarshaler(t) || isTextMarshaler(t)):
+		s := &spec.Schema{}
+		s.Ref = &ptr.Pointer
+		return s, true, nil
+
+	// json.Marshaler can produce anything, we have no way to tell
+	case isJSONMarshaler(t):
+		err := errors.Errorf("type %q implements json.Marshaler, its schema has to be provided by //openapi:type command or override", t.String())
+		return nil, true, err
+
+	case isTextMarshaler(t):
+		s, err := basic2schema(types.String, tg)
+		return s, true, err
+	}
+
+	switch t.Underlying().(type) {
+	// named basic types might be enums
+	case *types.Basic:
+		if m.mode&ProtoJSON != 0 {
+			if s, ok, err := protoEnum2schema(t, m, tg); ok {
+				return s, true, err
+			}
+		}
+		s, err := enum2schema(t, m, tg)
+		return s, true, err
+
+	// interfaces are supported only if they are polymorphic
+	case *types.Interface:
+		o, ok := m.oneofs[ptr.String()]
+		if !ok {
+			return nil, false, nil
+		}
+		s, err := oneof2schema(o, t, m, tg)
+		return s, true, err
+	}
+
+	return nil, false, nil
+}
+
 func typeElement2schema(t elementer, m pointmap, tp path, tg tag.Tag) (s *spec.Schema, err error) {
 	// Saying if element is slice, map, array, pointer and its inner element
 	// is same as its outer element it is invalid type for scheme.
@@ -184,29 +277,49 @@ func struct2schema(t *types.Struct, m pointmap, tp path) (s *spec.Schema, err er
 	s = &spec.Schema{}
 	s.Type = spec.TypeObject
 	s.Properties = make(map[string]*spec.Schema)
+	s.XML = structXML(t)
 
-	fields, err := collectStructFields(t, path{})
+	fields, err := collectStructFields(t, m, path{})
 	if err != nil {
 		return
 	}
 
+	var composed, oneofs []*spec.Schema
+	proto := m.mode&ProtoJSON != 0 && isProtoMessage(fields)
+	// xml is not described for protobuf messages
+	xmlStruct := !proto && describesXML(fields)
+
 	for _, x := range fields {
+		if proto && x.tag.Proto != nil && len(x.tag.Proto.OneofName) > 0 {
+			var o *spec.Schema
+			o, err = protoOneof2schema(x.field, m, tp)
+			if err != nil {
+				return
+			}
+			oneofs = append(oneofs, o)
+			continue
+		}
+		if proto && !isProtoField(x) {
+			continue
+		}
+		if x.embed != nil {
+			ptr := namedPoint(x.embed)
+			composed = append(composed, &spec.Schema{
+				Refable: spec.Refable{Ref: &ptr.Pointer},
+			})
+			continue
+		}
+
 		var pschema *spec.Schema
 
 		if len(x.tag.Type) != 0 {
 			pschema, err = basicString2schema(x.tag.Type, x.tag)
 		} else {
-			switch z := x.field.Type().Underlying().(type) {
-			// struct
-			case *types.Struct:
-				pschema, err = reference2schema(z, m, tp, x.tag)
-
-			default:
-				pschema, err = type2schema(z, m, tp, x.tag)
-			}
+			pschema, err = type2schema(x.field.Type(), m, tp, x.tag)
 		}
 
 		if err != nil {
+			err = diag.At(m.position(x.field), errors.Wrapf(err, "field %q", x.field.Name()))
 			return
 		}
 
@@ -214,32 +327,115 @@ func struct2schema(t *types.Struct, m pointmap, tp path) (s *spec.Schema, err er
 		if len(x.tag.Name) > 0 {
 			name = x.tag.Name
 		}
+		if proto {
+			name = x.tag.Proto.JSON()
+			protoQuote(pschema, x.field.Type())
+		}
 
 		s.Properties[name] = pschema
 
+		xml := !isXMLName(x.field) && !proto && hasXML(x.tag, x.field.Name(), name, xmlStruct)
+
+		if isRequired(x, m.mode) {
+			s.Required = append(s.Required, name)
+		}
+
+		doc, hasDoc := m.docs.of(x.field)
+
+		// siblings of $ref are ignored, values are set on wrapper instead
+		// and parsed according to schema of referenced type
+		var target *spec.Schema
+		if pschema.Ref != nil && (hasDoc || hasValues(x.tag) || xml) {
+			if hasValues(x.tag) {
+				target = refTarget(x.field.Type(), m, tp)
+			}
+			pschema = &spec.Schema{AllOf: []*spec.Schema{pschema}}
+			s.Properties[name] = pschema
+		}
+
 		if pschema.Ref != nil {
 			continue
 		}
 
+		if hasDoc {
+			pschema.Description = doc
+		}
+
 		pschema.Deprecated = x.tag.Deprecated
 		pschema.ReadOnly = x.tag.ReadOnly
 		pschema.WriteOnly = x.tag.WriteOnly
-		pschema.Format = x.tag.Format
+		if len(x.tag.Format) > 0 {
+			pschema.Format = x.tag.Format
+		}
 
 		if x.tag.Nullable != nil {
 			pschema.Nullable = *x.tag.Nullable
 		}
 
-		if x.tag.Required {
-			s.Required = append(s.Required, name)
+		err = tagValues(pschema, target, x.tag)
+		if err != nil {
+			err = diag.At(m.position(x.field), errors.Wrapf(err, "field %q", x.field.Name()))
+			return
+		}
+
+		if xml {
+			err = xml2schema(pschema, x.tag, x.field.Name(), name)
+			if err != nil {
+				err = diag.At(m.position(x.field), errors.Wrapf(err, "field %q", x.field.Name()))
+				return
+			}
 		}
 	}
 
-	return
+	// single oneof is set directly, multiple have to be composed
+	switch {
+	case len(oneofs) == 1:
+		s.OneOf = oneofs[0].OneOf
+	case len(oneofs) > 1:
+		s.AllOf = oneofs
+	}
+
+	if len(composed) == 0 {
+		return
+	}
+
+	// struct extends embedded types
+	if len(s.Properties) > 0 {
+		composed = append(composed, s)
+	}
+	return &spec.Schema{AllOf: composed}, nil
+}
+
+// refTarget returns schema of type which field refers to. Schemas of types
+// which are provided by commands or overrides are unknown, empty schema
+// is returned for those.
+func refTarget(t types.Type, m pointmap, tp path) *spec.Schema {
+	if p, ok := t.(*types.Pointer); ok {
+		t = p.Elem()
+	}
+	s, err := type2schema(t, m, tp, tag.Tag{})
+	if err != nil || s.Ref != nil {
+		return &spec.Schema{}
+	}
+	return s
+}
+
+// isRequired reports if field is required, unless required option is set
+// in tag fields might be inferred as required when they are always
+// present in json, ie. they are neither omitempty nor pointers.
+func isRequired(x structField, mode Mode) bool {
+	if x.tag.Required || x.tag.NotRequired {
+		return x.tag.Required
+	}
+	if mode&InferRequired == 0 {
+		return false
+	}
+	_, ptr := x.field.Type().Underlying().(*types.Pointer)
+	return !x.tag.OmitEmpty && !ptr
 }
 
 func map2schema(t *types.Map, m pointmap, tp path, tg tag.Tag) (s *spec.Schema, err error) {
-	sch, err := typeElement2schema(t, m, tp, tg)
+	sch, err := typeElement2schema(t, m, tp, elementTag(tg))
 	if err != nil {
 		return
 	}
@@ -249,6 +445,11 @@ func map2schema(t *types.Map, m pointmap, tp path, tg tag.Tag) (s *spec.Schema,
 	s.AdditionalProperties = sch
 	s.Nullable = true
 
+	err = mapKey2schema(s, t.Key(), m)
+	if err != nil {
+		return
+	}
+
 	s.MinProperties = tg.MinProps
 	s.MaxProperties = tg.MaxProps
 	if tg.Nullable != nil {
@@ -258,8 +459,90 @@ func map2schema(t *types.Map, m pointmap, tp path, tg tag.Tag) (s *spec.Schema,
 	return
 }
 
+// propertyNamesExtension holds propertyNames schema, keyword is not allowed
+// by OpenAPI 3.0, so it becomes propertyNames only for OpenAPI 3.1.
+const propertyNamesExtension = "x-propertyNames"
+
+// mapKey2schema describes keys of map the way encoding/json encodes them.
+// Keys of enum types are closed set of properties, other keys are described
+// by propertyNames. Keys which encoding/json can not encode are reported.
+func mapKey2schema(s *spec.Schema, k types.Type, m pointmap) error {
+	k = types.Unalias(k)
+	named, _ := k.(*types.Named)
+	basic, _ := k.Underlying().(*types.Basic)
+
+	// string keys are used as they are, even if they are marshalers
+	isString := basic != nil && basic.Info()&types.IsString != 0
+	isInteger := basic != nil && basic.Info()&types.IsInteger != 0
+
+	switch {
+	case isString || (isInteger && !types.Implements(k, textMarshaler)):
+		if named != nil {
+			if cc := enumConsts(named, m); len(cc) > 0 {
+				enumKeys(s, cc)
+				return nil
+			}
+		}
+		if isInteger {
+			setPropertyNames(s, quoted2schema(basic.Kind(), &spec.Schema{}, tag.Tag{}))
+		}
+		return nil
+
+	case types.Implements(k, textMarshaler):
+		if named == nil {
+			return nil
+		}
+		ptr := namedPoint(named)
+		if sch, ok := m.registry.get(ptr.Pointer); ok && sch.Type == spec.TypeString {
+			setPropertyNames(s, sch)
+		}
+		return nil
+
+	default:
+		return errors.Errorf("unsupported map key type %q", k.String())
+	}
+}
+
+// setPropertyNames sets schema of property names as extension
+func setPropertyNames(s, names *spec.Schema) {
+	if s.Extensions == nil {
+		s.Extensions = make(spec.Extensions)
+	}
+	s.Extensions[propertyNamesExtension] = spec.NewAny(names)
+}
+
+// enumKeys sets every value of enum as property of map, no other
+// properties are allowed.
+func enumKeys(s *spec.Schema, cc []*types.Const) {
+	s.Properties = make(map[string]*spec.Schema)
+	for _, c := range cc {
+		name := c.Val().ExactString()
+		if c.Val().Kind() == constant.String {
+			name = constant.StringVal(c.Val())
+		}
+		v := *s.AdditionalProperties
+		s.Properties[name] = &v
+	}
+	// schema matching nothing, same as additionalProperties: false
+	s.AdditionalProperties = &spec.Schema{Not: &spec.Schema{}}
+}
+
 func slice2schema(t *types.Slice, m pointmap, tp path, tg tag.Tag) (s *spec.Schema, err error) {
-	sch, err := typeElement2schema(t, m, tp, tg)
+	// encoding/json encodes byte slices as base64 strings
+	if b, ok := t.Elem().(*types.Basic); ok && b.Kind() == types.Byte {
+		s, err = basic2schema(types.String, elementTag(tg))
+		if err != nil {
+			return
+		}
+		s.Format = "byte"
+		s.Nullable = true
+		if tg.Nullable != nil {
+			s.Nullable = *tg.Nullable
+		}
+		return
+	}
+
+	sch, err := typeElement2schema(t, m, tp, elementTag(tg))
 	if err != nil {
 		return
 	}
@@ -280,7 +563,7 @@ func slice2schema(t *types.Slice, m pointmap, tp path, tg tag.Tag) (s *spec.Sche
 }
 
 func array2schema(t *types.Array, m pointmap, tp path, tg tag.Tag) (s *spec.Schema, err error) {
-	sch, err := typeElement2schema(t, m, tp, tg)
+	sch, err := typeElement2schema(t, m, tp, elementTag(tg))
 	if err != nil {
 		return
 	}
@@ -334,24 +617,13 @@ func pointer2schema(t *types.Pointer, m pointmap, tp path, tg tag.Tag) (s *spec.
 	return s, nil
 }
 
-var refStdMapping = map[string]spec.Schema{
-	"go://time#/Time": {
-		Type: spec.TypeString,
-	},
-}
-
-// AddRefOverride allows to add default override
-func AddRefOverride(p pointer.Pointer, s spec.Schema) {
-	refStdMapping[p.String()] = s
-}
-
 func reference2schema(t types.Type, m pointmap, tp path, tg tag.Tag) (s *spec.Schema, err error) {
 	ptr, ok := m.pick(t)
 	if !ok {
-		return nil, errors.New("failed to resolve sturct")
+		return nil, errors.Errorf("failed to resolve type %q", t.String())
 	}
-	if sch, ok := refStdMapping[ptr.String()]; ok {
-		return &sch, nil
+	if sch, ok := m.registry.get(ptr.Pointer); ok {
+		return sch, nil
 	}
 	s = &spec.Schema{}
 	s.Ref = &ptr.Pointer
@@ -432,9 +704,51 @@ func basic2schema(t types.BasicKind, tg tag.Tag) (s *spec.Schema, err error) {
 		s.Pattern = tg.Pattern
 	}
 
+	if tg.String {
+		return quoted2schema(t, s, tg), nil
+	}
+
 	return s, nil
 }
 
+// quoted2schema describes basic type encoded as json string, this is
+// what encoding/json does for fields with ",string" option.
+func quoted2schema(t types.BasicKind, s *spec.Schema, tg tag.Tag) *spec.Schema {
+	q := spec.StringProperty()
+	q.Format = s.Format
+	q.Pattern = tg.Pattern
+	info := types.Typ[t].Info()
+	switch {
+	case info&types.IsString != 0:
+		return s
+	case info&types.IsBoolean != 0:
+		q.Enum = []spec.Any{spec.NewAny("true"), spec.NewAny("false")}
+		return q
+	case len(q.Pattern) > 0:
+		return q
+	case info&types.IsUnsigned != 0:
+		q.Pattern = `^[0-9]+$`
+	case info&types.IsInteger != 0:
+		q.Pattern = `^-?[0-9]+$`
+	case info&types.IsFloat != 0:
+		q.Pattern = `^-?[0-9]+(\.[0-9]+)?([eE][-+]?[0-9]+)?$`
+	}
+	return q
+}
+
+// elementTag returns tag applied to elements of slices, arrays and maps,
+// ",string" option of encoding/json is not applied to elements.
+func elementTag(tg tag.Tag) tag.Tag {
+	tg.String = false
+	return tg
+}
+
+// TypeSchema returns schema of type given by its name. Names are
+// same as in type option of oapi tag (string, int64, uuid, ...).
+func TypeSchema(name string) (*spec.Schema, error) {
+	return basicString2schema(name, tag.Tag{})
+}
+
 func basicString2schema(t string, tg tag.Tag) (s *spec.Schema, err error) {
 	y := strings.Trim(t, " ")
 	switch y {
@@ -506,12 +820,42 @@ func castInlineStruct(t *types.Var, tg tag.Tag) (*types.Struct, bool) {
 	return st, ok
 }
 
+// castComposed reports if embedded field should be composed by allOf
+// instead of being inlined. This is the case if compose option is set
+// in tag or if embedded type is exported and ComposeEmbedded mode is set.
+func castComposed(t *types.Var, tg tag.Tag, m pointmap) (*types.Named, bool) {
+	if !t.Embedded() || (tg.Inline != nil && *tg.Inline) {
+		return nil, false
+	}
+	tx := types.Unalias(t.Type())
+	if p, ok := tx.(*types.Pointer); ok {
+		tx = types.Unalias(p.Elem())
+	}
+	named, ok := tx.(*types.Named)
+	if !ok {
+		return nil, false
+	}
+	if _, ok := named.Underlying().(*types.Struct); !ok {
+		return nil, false
+	}
+	if tg.Compose != nil {
+		return named, *tg.Compose
+	}
+	if m.mode&ComposeEmbedded == 0 {
+		return nil, false
+	}
+	return named, m.exports[namedPoint(named).String()]
+}
+
+// structField is field of struct, fields of inlined structs are
+// collected as well. Embedded types which are composed have embed set.
 type structField struct {
 	field *types.Var
 	tag   tag.Tag
+	embed *types.Named
 }
 
-func collectStructFields(t *types.Struct, p path) (arr []structField, err error) {
+func collectStructFields(t *types.Struct, m pointmap, p path) (arr []structField, err error) {
 	// prevent cycles
 	if p.has(t) {
 		return []structField{}, nil
@@ -527,10 +871,18 @@ func collectStructFields(t *types.Struct, p path) (arr []structField, err error)
 		if tx.Ignore || !x.Exported() {
 			continue
 		}
+		if named, ok := castComposed(x, tx, m); ok {
+			arr = append(arr, structField{
+				field: x,
+				tag:   tx,
+				embed: named,
+			})
+			continue
+		}
 		st, ok := castInlineStruct(x, tx)
 		if ok {
 			var z []structField
-			z, err = collectStructFields(st, p)
+			z, err = collectStructFields(st, m, p)
 			if err != nil {
 				return
 			}
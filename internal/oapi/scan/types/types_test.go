@@ -11,19 +11,26 @@ import (
 	"testing"
 
 	"github.com/buypal/oapi-go/internal/container"
-	"github.com/buypal/oapi-go/internal/pointer"
+	"github.com/buypal/oapi-go/pointer"
+	"github.com/buypal/oapi-go/spec"
 	"github.com/buypal/oapi-go/tag"
 	"github.com/stretchr/testify/require"
 )
 
 func pkgFor(source string, info *types.Info) (*types.Package, error) {
+	pkg, _, err := pkgFileFor(source, info)
+	return pkg, err
+}
+
+func pkgFileFor(source string, info *types.Info) (*types.Package, *ast.File, error) {
 	fset := token.NewFileSet()
-	f, err := parser.ParseFile(fset, "", source, 0)
+	f, err := parser.ParseFile(fset, "", source, parser.ParseComments)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	conf := types.Config{Importer: importer.Default()}
-	return conf.Check(f.Name.Name, fset, []*ast.File{f}, info)
+	pkg, err := conf.Check(f.Name.Name, fset, []*ast.File{f}, info)
+	return pkg, f, err
 }
 
 func mustTypecheck(t *testing.T, source string, info *types.Info) {
@@ -380,6 +387,7 @@ type test struct {
 properties:
   A:
     type: string
+    format: date-time
 type: object
 `,
 		},
@@ -408,3 +416,1435 @@ type: object
 		})
 	}
 }
+
+// scanSource will compile source and scan given types same way
+// Scanner does with packages.
+func scanSource(t *testing.T, src string, mode Mode, names ...string) *Scanner {
+	pkg, f, err := pkgFileFor(src, nil)
+	require.NoError(t, err)
+
+	r := NewScanner(nil, mode)
+	r.points.addFiles(pkg.Path(), f)
+	for _, n := range names {
+		obj := pkg.Scope().Lookup(n)
+		require.NotNil(t, obj, "could not found type %q", n)
+		require.NoError(t, collectTypes(obj, &r.points))
+	}
+	return r
+}
+
+// requireYAML compares schema with expected yaml
+func requireYAML(t *testing.T, expected string, v interface{}) {
+	c1, err := container.ReadYAML([]byte(strings.Trim(expected, "\n\t")))
+	require.NoError(t, err)
+
+	c2, err := container.Make(v)
+	require.NoError(t, err)
+
+	e1, _ := c1.MarshalYAML()
+	e2, _ := c2.MarshalYAML()
+	require.Equal(t, string(e1), string(e2))
+}
+
+func TestDescriptions(t *testing.T) {
+	src := `package test
+
+// test is object with docs.
+// It has two fields.
+type test struct {
+	// A is documented field.
+	A string
+	B int // B has line comment
+	C string
+	// D is documented field of named type.
+	D owner
+	E owner
+}
+
+// owner is documented type.
+type owner struct {
+	Name string
+}
+`
+	r := scanSource(t, src, 0, "test")
+
+	sp, err := r.Resolve(mustPoint(t, "test"))
+	require.NoError(t, err)
+	require.Equal(t, "test is object with docs.", sp.Title)
+	require.Equal(t, "test is object with docs.\nIt has two fields.", sp.Description)
+	require.Equal(t, "A is documented field.", sp.Properties["A"].Description)
+	require.Equal(t, "B has line comment", sp.Properties["B"].Description)
+	require.Equal(t, "", sp.Properties["C"].Description)
+	require.Equal(t, "D is documented field of named type.", sp.Properties["D"].Description)
+	require.Len(t, sp.Properties["D"].AllOf, 1)
+	require.Equal(t, mustPoint(t, "owner"), *sp.Properties["D"].AllOf[0].Ref)
+	require.Equal(t, mustPoint(t, "owner"), *sp.Properties["E"].Ref)
+
+	sp, err = r.Resolve(mustPoint(t, "test/B"))
+	require.NoError(t, err)
+	require.Equal(t, "", sp.Title)
+	require.Equal(t, "B has line comment", sp.Description)
+}
+
+func TestEnums(t *testing.T) {
+	src := `package test
+
+type Status string
+
+const (
+	StatusActive   Status = "active"
+	StatusInactive Status = "inactive"
+	StatusDefault         = StatusActive
+)
+
+type Level int
+
+const (
+	LevelLow Level = iota + 1
+	LevelHigh
+)
+
+var levelNames = map[Level]string{
+	LevelLow:  "low",
+	LevelHigh: "high",
+}
+
+func (l Level) String() string { return levelNames[l] }
+
+type Kind uint8
+
+const (
+	KindA Kind = iota
+	KindB
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindA:
+		return "a"
+	case KindB:
+		return "b"
+	}
+	return ""
+}
+
+type Plain int
+
+type test struct {
+	S Status
+	L Level
+	K *Kind
+	P Plain
+}
+`
+	r := scanSource(t, src, 0, "test", "Status")
+
+	sp, err := r.Resolve(mustPoint(t, "test"))
+	require.NoError(t, err)
+	requireYAML(t, `
+type: object
+properties:
+  S:
+    type: string
+    enum: [active, inactive]
+  L:
+    type: integer
+    format: int32
+    enum: [1, 2]
+  K:
+    type: integer
+    format: int32
+    minimum: 0
+    enum: [0, 1]
+    nullable: true
+  P:
+    type: integer
+    format: int32
+`, sp)
+
+	sp, err = r.Resolve(mustPoint(t, "Status"))
+	require.NoError(t, err)
+	requireYAML(t, `
+type: string
+enum: [active, inactive]
+`, sp)
+
+	r = scanSource(t, src, EnumStringer, "test")
+
+	sp, err = r.Resolve(mustPoint(t, "test"))
+	require.NoError(t, err)
+	requireYAML(t, `
+type: object
+properties:
+  S:
+    type: string
+    enum: [active, inactive]
+  L:
+    type: string
+    enum: [low, high]
+  K:
+    type: string
+    enum: [a, b]
+    nullable: true
+  P:
+    type: integer
+    format: int32
+`, sp)
+}
+
+func TestEnumsStringerTool(t *testing.T) {
+	src := `package test
+
+import "strconv"
+
+type Pill int
+
+const (
+	Placebo Pill = iota + 1
+	Aspirin
+	Ibuprofen
+)
+
+const _Pill_name = "PlaceboAspirinIbuprofen"
+
+var _Pill_index = [...]uint8{0, 7, 14, 23}
+
+func (i Pill) String() string {
+	i -= 1
+	if i < 0 || i >= Pill(len(_Pill_index)-1) {
+		return "Pill(" + strconv.FormatInt(int64(i+1), 10) + ")"
+	}
+	return _Pill_name[_Pill_index[i]:_Pill_index[i+1]]
+}
+
+type Color uint8
+
+const (
+	Red Color = iota
+	Green
+	Blue Color = 10
+)
+
+const (
+	_Color_name_0 = "RedGreen"
+	_Color_name_1 = "Blue"
+)
+
+var (
+	_Color_index_0 = [...]uint8{0, 3, 8}
+)
+
+func (i Color) String() string {
+	switch {
+	case i <= 1:
+		return _Color_name_0[_Color_index_0[i]:_Color_index_0[i+1]]
+	case i == 10:
+		return _Color_name_1
+	default:
+		return "Color(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+}
+
+type Size int
+
+const (
+	Small Size = 1
+	Large Size = 100
+)
+
+const _Size_name = "SmallLarge"
+
+var _Size_map = map[Size]string{
+	1:   _Size_name[0:5],
+	100: _Size_name[5:10],
+}
+
+func (i Size) String() string {
+	if str, ok := _Size_map[i]; ok {
+		return str
+	}
+	return "Size(" + strconv.FormatInt(int64(i), 10) + ")"
+}
+
+type Day int
+
+const (
+	Monday Day = iota
+	Tuesday
+)
+
+const _Day_name = "MondayTuesday"
+
+var _Day_index = [...]uint8{0, 6, 13}
+
+func (i Day) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_Day_index)-1 {
+		return "Day(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Day_name[_Day_index[idx]:_Day_index[idx+1]]
+}
+
+type test struct {
+	P Pill
+	C Color
+	S Size
+	D Day
+}
+`
+	r := scanSource(t, src, EnumStringer, "test")
+
+	sp, err := r.Resolve(mustPoint(t, "test"))
+	require.NoError(t, err)
+	requireYAML(t, `
+type: object
+properties:
+  P:
+    type: string
+    enum: [Placebo, Aspirin, Ibuprofen]
+  C:
+    type: string
+    enum: [Red, Green, Blue]
+  S:
+    type: string
+    enum: [Small, Large]
+  D:
+    type: string
+    enum: [Monday, Tuesday]
+`, sp)
+	invalid := map[string]string{
+		// String() which is neither generated nor simple
+		"computed": `package test
+
+import "fmt"
+
+type Level int
+
+const (
+	Low Level = iota
+	High
+)
+
+func (l Level) String() string { return fmt.Sprintf("level-%d", int(l)) }
+
+type test struct {
+	L Level
+}
+`,
+		// tables are stale, constant was added
+		"stale": `package test
+
+type Day int
+
+const (
+	Monday Day = iota
+	Tuesday
+	Wednesday
+)
+
+const _Day_name = "MondayTuesday"
+
+var _Day_index = [...]uint8{0, 6, 13}
+
+func (i Day) String() string { return _Day_name[_Day_index[i]:_Day_index[i+1]] }
+
+type test struct {
+	D Day
+}
+`,
+	}
+	for name, src := range invalid {
+		r := scanSource(t, src, EnumStringer, "test")
+		_, err := r.Resolve(mustPoint(t, "test"))
+		require.Error(t, err, name)
+	}
+}
+
+func TestMarshalers(t *testing.T) {
+	src := `package test
+
+type Money struct {
+	Amount   int
+	Currency string
+}
+
+func (m Money) MarshalText() ([]byte, error) { return nil, nil }
+
+type Raw struct {
+	A string
+}
+
+func (r *Raw) MarshalJSON() ([]byte, error) { return nil, nil }
+
+type test struct {
+	M Money
+	R Raw
+}
+`
+	r := scanSource(t, src, 0, "test")
+
+	sp, err := r.Resolve(mustPoint(t, "test"))
+	require.NoError(t, err)
+	requireYAML(t, `
+type: object
+properties:
+  M:
+    $ref: go://test#/Money
+  R:
+    $ref: go://test#/Raw
+`, sp)
+
+	sp, err = r.Resolve(mustPoint(t, "Money"))
+	require.NoError(t, err)
+	requireYAML(t, `type: string`, sp)
+
+	_, err = r.Resolve(mustPoint(t, "Raw"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `"test.Raw" implements json.Marshaler`)
+}
+
+func TestOneOf(t *testing.T) {
+	src := `package test
+
+type Shape interface {
+	Area() float64
+}
+
+type Circle struct {
+	Kind string ` + "`json:\"kind\"`" + `
+}
+
+func (Circle) Area() float64 { return 0 }
+
+type Square struct {
+	Kind string ` + "`json:\"kind\"`" + `
+}
+
+func (*Square) Area() float64 { return 0 }
+
+type Line struct {
+	Kind string ` + "`json:\"kind\"`" + `
+}
+
+type test struct {
+	S Shape
+}
+`
+	r := NewScanner(nil, 0)
+	r.AddOneOf(OneOf{
+		Ptr:           mustPoint(t, "Shape"),
+		Discriminator: "kind",
+		Variants: []Variant{
+			{Ptr: mustPoint(t, "Circle"), Value: "circle"},
+			{Ptr: mustPoint(t, "Square")},
+		},
+	})
+	pkg, f, err := pkgFileFor(src, nil)
+	require.NoError(t, err)
+	r.points.addFiles(pkg.Path(), f)
+	require.NoError(t, collectTypes(pkg.Scope().Lookup("test"), &r.points))
+
+	sp, err := r.Resolve(mustPoint(t, "test"))
+	require.NoError(t, err)
+	requireYAML(t, `
+type: object
+properties:
+  S:
+    oneOf:
+    - $ref: go://test#/Circle
+    - $ref: go://test#/Square
+    discriminator:
+      propertyName: kind
+      mapping:
+        circle: go://test#/Circle
+        Square: go://test#/Square
+`, sp)
+
+	// listed variant has to implement interface
+	r = NewScanner(nil, 0)
+	r.AddOneOf(OneOf{
+		Ptr:      mustPoint(t, "Shape"),
+		Variants: []Variant{{Ptr: mustPoint(t, "Circle")}, {Ptr: mustPoint(t, "Line")}},
+	})
+	r.points.addFiles(pkg.Path(), f)
+	require.NoError(t, collectTypes(pkg.Scope().Lookup("Line"), &r.points))
+	require.NoError(t, collectTypes(pkg.Scope().Lookup("test"), &r.points))
+	_, err = r.Resolve(mustPoint(t, "test"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `variant "go://test#/Line" does not implement interface "test.Shape"`)
+
+	// variants are discovered
+	r = NewScanner(nil, 0)
+	r.AddOneOf(OneOf{Ptr: mustPoint(t, "Shape")})
+	r.collectCandidates(pkg.Scope())
+	require.NoError(t, collectTypes(pkg.Scope().Lookup("Shape"), &r.points))
+	require.NoError(t, collectTypes(pkg.Scope().Lookup("test"), &r.points))
+
+	exp, err := r.ExportedComponents()
+	require.NoError(t, err)
+	require.Len(t, exp, 2)
+	require.Equal(t, "Circle", exp[0].Name)
+	require.Equal(t, "Square", exp[1].Name)
+
+	sp, err = r.Resolve(mustPoint(t, "test/S"))
+	require.NoError(t, err)
+	requireYAML(t, `
+oneOf:
+- $ref: go://test#/Circle
+- $ref: go://test#/Square
+`, sp)
+
+	// interface which is not polymorphic is not supported
+	r = scanSource(t, src, 0, "test")
+	_, err = r.Resolve(mustPoint(t, "test"))
+	require.Error(t, err)
+}
+
+func TestGenerics(t *testing.T) {
+	src := `package test
+
+type Item struct {
+	Name string
+}
+
+type Other struct {
+	ID int
+}
+
+// Page is generic envelope.
+type Page[T any] struct {
+	Items []T
+	Next  *Page[T]
+}
+
+type Pair[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
+type test struct {
+	A Page[Item]
+	B Page[Other]
+}
+`
+	r := scanSource(t, src, 0, "test")
+
+	sp, err := r.Resolve(mustPoint(t, "test"))
+	require.NoError(t, err)
+	requireYAML(t, `
+type: object
+properties:
+  A:
+    $ref: go://test#/Page[Item]
+  B:
+    $ref: go://test#/Page[Other]
+`, sp)
+
+	sp, err = r.Resolve(mustPoint(t, "Page[Other]"))
+	require.NoError(t, err)
+	require.Equal(t, "Page is generic envelope.", sp.Title)
+	sp.Title, sp.Description = "", ""
+	requireYAML(t, `
+type: object
+properties:
+  Items:
+    type: array
+    items:
+      $ref: go://test#/Other
+    nullable: true
+  Next:
+    oneOf:
+    - $ref: go://test#/Page[Other]
+    - type: object
+      nullable: true
+`, sp)
+
+	// pointers to instances which are not used in scanned types
+	pkg, _, err := pkgFileFor(src, nil)
+	require.NoError(t, err)
+	r.addPkg(pkg)
+	r.collectInstance(mustPoint(t, "Pair[string,[]Item]"), "Pair[string,[]Item]")
+	sp, err = r.Resolve(mustPoint(t, "Pair[string,[]Item]/Value"))
+	require.NoError(t, err)
+	requireYAML(t, `
+type: array
+items:
+  $ref: go://test#/Item
+nullable: true
+`, sp)
+
+	r.collectInstance(mustPoint(t, "Page[Missing]"), "Page[Missing]")
+	_, err = r.Resolve(mustPoint(t, "Page[Missing]"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `type "Missing" not found`)
+
+	_, err = r.Resolve(mustPoint(t, "Page"))
+	require.Error(t, err)
+
+	require.Equal(t, "PageItem", ComponentName("Page[Item]"))
+	require.Equal(t, "PageItem", ComponentName("Page[items.Item]"))
+	require.Equal(t, "PairStringItem", ComponentName("Pair[string,*Item]"))
+}
+
+func TestQuoted(t *testing.T) {
+	src := `package test
+
+type Level int
+
+const (
+	LevelLow Level = iota + 1
+	LevelHigh
+)
+
+type test struct {
+	ID    int64   ` + "`json:\"id,string\"`" + `
+	Count uint    ` + "`json:\"count,string\"`" + `
+	Ratio float64 ` + "`json:\"ratio,string\"`" + `
+	Flag  bool    ` + "`json:\"flag,string\"`" + `
+	Name  string  ` + "`json:\"name,string\"`" + `
+	Ptr   *int64  ` + "`json:\"ptr,string\" oapi:\",nullable:false\"`" + `
+	Lvl   Level   ` + "`json:\"lvl,string\"`" + `
+	IDs   []int64 ` + "`json:\"ids,string\"`" + `
+	Money int64   ` + "`oapi:\"money,string,pattern:^[0-9]{2}$\"`" + `
+}
+`
+	r := scanSource(t, src, 0, "test")
+
+	sp, err := r.Resolve(mustPoint(t, "test"))
+	require.NoError(t, err)
+	requireYAML(t, `
+type: object
+properties:
+  id:
+    type: string
+    format: int64
+    pattern: ^-?[0-9]+$
+  count:
+    type: string
+    format: int32
+    pattern: ^[0-9]+$
+  ratio:
+    type: string
+    format: double
+    pattern: ^-?[0-9]+(\.[0-9]+)?([eE][-+]?[0-9]+)?$
+  flag:
+    type: string
+    enum: ["true", "false"]
+  name:
+    type: string
+  ptr:
+    type: string
+    format: int64
+    pattern: ^-?[0-9]+$
+  lvl:
+    type: string
+    format: int32
+    pattern: ^-?[0-9]+$
+    enum: ["1", "2"]
+  ids:
+    type: array
+    items:
+      type: integer
+      format: int64
+    nullable: true
+  money:
+    type: string
+    format: int64
+    pattern: ^[0-9]{2}$
+`, sp)
+}
+
+func TestInferRequired(t *testing.T) {
+	src := `package test
+
+type Item struct {
+	Name string
+}
+
+type test struct {
+	A string
+	B string  ` + "`json:\"b,omitempty\"`" + `
+	C *string
+	D Item
+	E string  ` + "`json:\"e\" oapi:\"required:false\"`" + `
+	F *string ` + "`oapi:\"f,required\"`" + `
+	G string  ` + "`json:\"g,omitempty\" oapi:\"g,readonly\"`" + `
+	H string  ` + "`json:\"h\" oapi:\"required=false\"`" + `
+	I string  ` + "`json:\"i\" oapi:\"i,required=false\"`" + `
+}
+`
+	r := scanSource(t, src, 0, "test")
+	sp, err := r.Resolve(mustPoint(t, "test"))
+	require.NoError(t, err)
+	require.Equal(t, []string{"f"}, sp.Required)
+
+	r = scanSource(t, src, InferRequired, "test")
+	sp, err = r.Resolve(mustPoint(t, "test"))
+	require.NoError(t, err)
+	require.Equal(t, []string{"A", "D", "f"}, sp.Required)
+	require.Contains(t, sp.Properties, "h")
+	require.Contains(t, sp.Properties, "i")
+}
+
+func TestComposeEmbedded(t *testing.T) {
+	src := `package test
+
+type User struct {
+	Name string
+}
+
+type Audit struct {
+	Created string
+}
+
+type AdminUser struct {
+	*User
+	Audit
+	Perms []string
+}
+
+type Composed struct {
+	Audit ` + "`oapi:\",compose\"`" + `
+}
+
+type Inlined struct {
+	User ` + "`oapi:\",compose:false\"`" + `
+}
+`
+	r := scanSource(t, src, 0, "AdminUser")
+	sp, err := r.Resolve(mustPoint(t, "AdminUser"))
+	require.NoError(t, err)
+	requireYAML(t, `
+type: object
+properties:
+  Name:
+    type: string
+  Created:
+    type: string
+  Perms:
+    type: array
+    items:
+      type: string
+    nullable: true
+`, sp)
+
+	r = scanSource(t, src, ComposeEmbedded, "AdminUser", "Composed", "Inlined")
+	r.AddExport(mustPoint(t, "User"))
+
+	sp, err = r.Resolve(mustPoint(t, "AdminUser"))
+	require.NoError(t, err)
+	requireYAML(t, `
+allOf:
+- $ref: go://test#/User
+- type: object
+  properties:
+    Created:
+      type: string
+    Perms:
+      type: array
+      items:
+        type: string
+      nullable: true
+`, sp)
+
+	sp, err = r.Resolve(mustPoint(t, "Composed"))
+	require.NoError(t, err)
+	requireYAML(t, `
+allOf:
+- $ref: go://test#/Audit
+`, sp)
+
+	sp, err = r.Resolve(mustPoint(t, "Inlined"))
+	require.NoError(t, err)
+	requireYAML(t, `
+type: object
+properties:
+  Name:
+    type: string
+`, sp)
+}
+
+func TestRegistry(t *testing.T) {
+	src := `package test
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net"
+	"time"
+)
+
+type Bytes []byte
+
+type test struct {
+	T  *time.Time
+	D  time.Duration
+	IP net.IP
+	R  json.RawMessage
+	NS sql.NullString
+	B  []byte
+	C  Bytes
+	M  Money
+}
+
+type Money struct {
+	Amount int
+}
+`
+	r := scanSource(t, src, 0, "test")
+	require.NoError(t, r.AddType("test.Money", spec.Schema{Type: spec.TypeString, Format: "decimal"}))
+	require.Error(t, r.AddType("Money", spec.Schema{}))
+
+	sp, err := r.Resolve(mustPoint(t, "test"))
+	require.NoError(t, err)
+	requireYAML(t, `
+type: object
+properties:
+  T:
+    type: string
+    format: date-time
+    nullable: true
+  D:
+    type: integer
+    format: int64
+    description: Duration in nanoseconds.
+  IP:
+    type: string
+    anyOf:
+    - type: string
+      format: ipv4
+    - type: string
+      format: ipv6
+  R: {}
+  NS:
+    type: object
+    properties:
+      String:
+        type: string
+      Valid:
+        type: boolean
+    required: [String, Valid]
+  B:
+    type: string
+    format: byte
+    nullable: true
+  C:
+    type: string
+    format: byte
+    nullable: true
+  M:
+    type: string
+    format: decimal
+`, sp)
+
+	// every use gets its own copy of schema
+	ptr, err := pointer.NewGoPointer("net", "IP")
+	require.NoError(t, err)
+	ip, ok := r.points.registry.get(ptr)
+	require.True(t, ok)
+	ip.AnyOf[0].Format = "changed"
+	ip, ok = r.points.registry.get(ptr)
+	require.True(t, ok)
+	require.Equal(t, "ipv4", ip.AnyOf[0].Format)
+
+	ptr, err = pointer.NewGoPointer("net/url", "URL")
+	require.NoError(t, err)
+	u, ok := r.points.registry.get(ptr)
+	require.True(t, ok)
+	require.Equal(t, spec.TypeString, u.Type)
+	require.Equal(t, "uri", u.Format)
+}
+
+func TestTagValues(t *testing.T) {
+	src := `package test
+
+type Item struct {
+	Name string
+}
+
+type test struct {
+	// Size is overridden by tag.
+	Size  int      ` + "`oapi:\"size,default:5,example:10,description:Page size\"`" + `
+	Kind  string   ` + "`oapi:\"kind,enum:a|b|c,default:a,title:Kind\"`" + `
+	Ratio *float64 ` + "`oapi:\"ratio,example:0.5,default:null\"`" + `
+	Flag  bool     ` + "`oapi:\"flag,default:true\"`" + `
+	ID    int64    ` + "`json:\"id,string\" oapi:\",example:42\"`" + `
+	Tags  []string ` + "`oapi:\"tags,example:'[\\\"x\\\",\\\"y\\\"]'\"`" + `
+	Item  Item     ` + "`oapi:\"item,example:'{\\\"Name\\\":\\\"n\\\"}'\"`" + `
+}
+
+type invalid struct {
+	Size int ` + "`oapi:\"size,default:big\"`" + `
+}
+`
+	r := scanSource(t, src, 0, "test", "invalid")
+
+	sp, err := r.Resolve(mustPoint(t, "test"))
+	require.NoError(t, err)
+	requireYAML(t, `
+type: object
+properties:
+  size:
+    type: integer
+    format: int32
+    default: 5
+    example: 10
+    description: Page size
+  kind:
+    type: string
+    title: Kind
+    default: a
+    enum: [a, b, c]
+  ratio:
+    type: number
+    format: double
+    nullable: true
+    example: 0.5
+    default: null
+  flag:
+    type: boolean
+    default: true
+  id:
+    type: string
+    format: int64
+    pattern: ^-?[0-9]+$
+    example: "42"
+  tags:
+    type: array
+    items:
+      type: string
+    nullable: true
+    example: [x, "y"]
+  item:
+    allOf:
+    - $ref: go://test#/Item
+    example:
+      Name: "n"
+`, sp)
+
+	_, err = r.Resolve(mustPoint(t, "invalid"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `type "go://test#/invalid": field "Size": invalid default: "big" is not an integer`)
+}
+
+func TestTagValuesForms(t *testing.T) {
+	src := `package test
+
+type Currency struct {
+	Code string
+}
+
+func (c Currency) MarshalText() ([]byte, error) { return []byte(c.Code), nil }
+
+type test struct {
+	Size     int      ` + "`oapi:\"size,default=5\"`" + `
+	Kind     string   ` + "`oapi:\"kind,enum=a|b|c\"`" + `
+	Currency Currency ` + "`oapi:\"currency,example:USD\"`" + `
+	Big      uint64   ` + "`oapi:\"big,example:18446744073709551615\"`" + `
+}
+`
+	r := scanSource(t, src, 0, "test")
+
+	sp, err := r.Resolve(mustPoint(t, "test"))
+	require.NoError(t, err)
+	// yaml would decode value of uint64 as float
+	require.Equal(t, spec.Any("18446744073709551615"), sp.Properties["big"].Example)
+	delete(sp.Properties, "big")
+	requireYAML(t, `
+type: object
+properties:
+  size:
+    type: integer
+    format: int32
+    default: 5
+  kind:
+    type: string
+    enum: [a, b, c]
+  currency:
+    allOf:
+    - $ref: go://test#/Currency
+    example: USD
+`, sp)
+}
+
+func TestSelfReferenceWithDoc(t *testing.T) {
+	src := `package test
+
+type Node struct {
+	// Next node
+	Next *Node ` + "`oapi:\"next,nullable:false\"`" + `
+	Prev *Node ` + "`oapi:\"prev,nullable:false,example:{}\"`" + `
+}
+`
+	r := scanSource(t, src, 0, "Node")
+
+	sp, err := r.Resolve(mustPoint(t, "Node"))
+	require.NoError(t, err)
+	requireYAML(t, `
+type: object
+properties:
+  next:
+    allOf:
+    - $ref: go://test#/Node
+    description: Next node
+  prev:
+    allOf:
+    - $ref: go://test#/Node
+    example: {}
+`, sp)
+}
+
+func TestXML(t *testing.T) {
+	src := `package test
+
+import "encoding/xml"
+
+type Book struct {
+	Title string
+}
+
+type Price struct {
+	Currency string ` + "`xml:\"currency,attr\"`" + `
+	Value    string ` + "`xml:\",chardata\"`" + `
+}
+
+type test struct {
+	XMLName xml.Name ` + "`json:\"-\" xml:\"http://example.com/ns library\"`" + `
+	ID      int      ` + "`xml:\"id,attr\"`" + `
+	Name    string   ` + "`xml:\"http://example.com/ns name\"`" + `
+	Books   []Book   ` + "`xml:\"books>book\"`" + `
+	Tags    []string ` + "`xml:\"tag\"`" + `
+	Owner   Book     ` + "`xml:\"owner\"`" + `
+	Price   Price
+	Skip    string   ` + "`xml:\"-\"`" + `
+}
+`
+	r := scanSource(t, src, 0, "test", "Price")
+
+	sp, err := r.Resolve(mustPoint(t, "test"))
+	require.NoError(t, err)
+	requireYAML(t, `
+type: object
+xml:
+  name: library
+  namespace: http://example.com/ns
+properties:
+  ID:
+    type: integer
+    format: int32
+    xml:
+      name: id
+      attribute: true
+  Name:
+    type: string
+    xml:
+      name: name
+      namespace: http://example.com/ns
+  Books:
+    type: array
+    items:
+      allOf:
+      - $ref: go://test#/Book
+      xml:
+        name: book
+    nullable: true
+    xml:
+      name: books
+      wrapped: true
+  Tags:
+    type: array
+    items:
+      type: string
+      xml:
+        name: tag
+    nullable: true
+  Owner:
+    allOf:
+    - $ref: go://test#/Book
+    xml:
+      name: owner
+  Price:
+    $ref: go://test#/Price
+  Skip:
+    type: string
+`, sp)
+
+	sp, err = r.Resolve(mustPoint(t, "Price"))
+	require.NoError(t, err)
+	requireYAML(t, `
+type: object
+properties:
+  Currency:
+    type: string
+    xml:
+      name: currency
+      attribute: true
+  Value:
+    type: string
+    xml:
+      x-text: true
+`, sp)
+}
+
+func TestXMLFieldNames(t *testing.T) {
+	src := `package test
+
+import "encoding/xml"
+
+type Book struct {
+	Title string
+}
+
+type Plain struct {
+	Title string ` + "`json:\"title\"`" + `
+}
+
+type test struct {
+	XMLName xml.Name ` + "`json:\"-\" xml:\"library\"`" + `
+	Title   string   ` + "`json:\"title\"`" + `
+	Lang    string   ` + "`json:\"lang\" xml:\",attr\"`" + `
+	Owner   Book     ` + "`json:\"owner\"`" + `
+	Books   []Book   ` + "`json:\"books\"`" + `
+	Same    string
+}
+
+type deep struct {
+	Books []Book ` + "`xml:\"a>b>book\"`" + `
+}
+
+type nested struct {
+	Title string ` + "`xml:\"info>title\"`" + `
+}
+`
+	r := scanSource(t, src, 0, "test", "Plain", "deep", "nested")
+
+	sp, err := r.Resolve(mustPoint(t, "test"))
+	require.NoError(t, err)
+	requireYAML(t, `
+type: object
+xml:
+  name: library
+properties:
+  title:
+    type: string
+    xml:
+      name: Title
+  lang:
+    type: string
+    xml:
+      name: Lang
+      attribute: true
+  owner:
+    allOf:
+    - $ref: go://test#/Book
+    xml:
+      name: Owner
+  books:
+    type: array
+    items:
+      allOf:
+      - $ref: go://test#/Book
+      xml:
+        name: Books
+    nullable: true
+  Same:
+    type: string
+`, sp)
+
+	// structs without xml tags are not described
+	sp, err = r.Resolve(mustPoint(t, "Plain"))
+	require.NoError(t, err)
+	require.Nil(t, sp.Properties["title"].XML)
+
+	_, err = r.Resolve(mustPoint(t, "deep"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `xml element chain "a>b>book" is supported only as wrapper of array`)
+
+	_, err = r.Resolve(mustPoint(t, "nested"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `xml element chain "info>title" is supported only as wrapper of array`)
+}
+
+func TestProtoJSON(t *testing.T) {
+	src := `package test
+
+type Status int32
+
+const (
+	Status_ACTIVE  Status = 1
+	Status_UNKNOWN Status = 0
+)
+
+var Status_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "ACTIVE",
+}
+
+type isUser_Contact interface {
+	isUser_Contact()
+}
+
+type User_Email struct {
+	Email string ` + "`protobuf:\"bytes,4,opt,name=email,proto3,oneof\"`" + `
+}
+
+type User_PhoneNumber struct {
+	PhoneNumber int64 ` + "`protobuf:\"varint,5,opt,name=phone_number,json=phoneNumber,proto3,oneof\"`" + `
+}
+
+func (*User_Email) isUser_Contact()       {}
+func (*User_PhoneNumber) isUser_Contact() {}
+
+type User struct {
+	state         struct{}
+	sizeCache     int32
+	unknownFields []byte
+
+	UserId  int64          ` + "`protobuf:\"varint,1,opt,name=user_id,json=userId,proto3\" json:\"user_id,omitempty\"`" + `
+	Status  Status         ` + "`protobuf:\"varint,2,opt,name=status,proto3,enum=test.Status\" json:\"status,omitempty\"`" + `
+	Scores  []uint64       ` + "`protobuf:\"varint,3,rep,packed,name=scores,proto3\" json:\"scores,omitempty\"`" + `
+	Contact isUser_Contact ` + "`protobuf_oneof:\"contact\"`" + `
+	Balance int64          ` + "`protobuf:\"varint,6,opt,name=balance,proto3\" json:\"balance,omitempty\" oapi:\",min:0\"`" + `
+
+	XXX_unrecognized []byte ` + "`json:\"-\"`" + `
+}
+
+type plain struct {
+	UserId int64
+}
+`
+	r := scanSource(t, src, ProtoJSON, "User", "Status", "plain")
+
+	sp, err := r.Resolve(mustPoint(t, "User"))
+	require.NoError(t, err)
+	requireYAML(t, `
+type: object
+properties:
+  userId:
+    type: string
+    format: int64
+    pattern: ^-?[0-9]+$
+  status:
+    type: string
+    enum:
+    - UNKNOWN
+    - ACTIVE
+  scores:
+    type: array
+    items:
+      type: string
+      format: int64
+      pattern: ^[0-9]+$
+    nullable: true
+  balance:
+    type: string
+    format: int64
+    pattern: ^-?[0-9]+$
+oneOf:
+- type: object
+  properties:
+    email:
+      type: string
+  required:
+  - email
+- type: object
+  properties:
+    phoneNumber:
+      type: string
+      format: int64
+      pattern: ^-?[0-9]+$
+  required:
+  - phoneNumber
+`, sp)
+
+	sp, err = r.Resolve(mustPoint(t, "Status"))
+	require.NoError(t, err)
+	requireYAML(t, `
+type: string
+enum:
+- UNKNOWN
+- ACTIVE
+`, sp)
+
+	sp, err = r.Resolve(mustPoint(t, "plain"))
+	require.NoError(t, err)
+	requireYAML(t, `
+type: object
+properties:
+  UserId:
+    type: integer
+    format: int64
+`, sp)
+
+	// well known types are registered only in ProtoJSON mode
+	ptr, err := typePointer("google.golang.org/protobuf/types/known/timestamppb.Timestamp")
+	require.NoError(t, err)
+	_, ok := r.points.registry.get(ptr)
+	require.True(t, ok)
+	_, ok = newRegistry(0).get(ptr)
+	require.False(t, ok)
+
+	ptr, err = typePointer("google.golang.org/protobuf/types/known/wrapperspb.UInt32Value")
+	require.NoError(t, err)
+	u32, ok := r.points.registry.get(ptr)
+	require.True(t, ok)
+	require.NotNil(t, u32.Minimum)
+	require.Equal(t, 0., *u32.Minimum)
+}
+
+func TestMapKeys(t *testing.T) {
+	src := `package test
+
+import "net/netip"
+
+type Color string
+
+const (
+	Red  Color = "red"
+	Blue Color = "blue"
+)
+
+type Level uint8
+
+const (
+	Low  Level = 1
+	High Level = 2
+)
+
+type test struct {
+	Str   map[string]int
+	Int   map[int]int
+	Uint  map[uint16]int
+	Color map[Color]int
+	Level map[Level]int
+	Addr  map[netip.Addr]int
+}
+`
+	r := scanSource(t, src, 0, "test")
+
+	sp, err := r.Resolve(mustPoint(t, "test"))
+	require.NoError(t, err)
+	requireYAML(t, `
+type: object
+properties:
+  Str:
+    type: object
+    additionalProperties:
+      type: integer
+      format: int32
+    nullable: true
+  Int:
+    type: object
+    x-propertyNames:
+      type: string
+      pattern: ^-?[0-9]+$
+    additionalProperties:
+      type: integer
+      format: int32
+    nullable: true
+  Uint:
+    type: object
+    x-propertyNames:
+      type: string
+      pattern: ^[0-9]+$
+    additionalProperties:
+      type: integer
+      format: int32
+    nullable: true
+  Color:
+    type: object
+    properties:
+      red:
+        type: integer
+        format: int32
+      blue:
+        type: integer
+        format: int32
+    additionalProperties:
+      not: {}
+    nullable: true
+  Level:
+    type: object
+    properties:
+      "1":
+        type: integer
+        format: int32
+      "2":
+        type: integer
+        format: int32
+    additionalProperties:
+      not: {}
+    nullable: true
+  Addr:
+    type: object
+    x-propertyNames:
+      type: string
+      anyOf:
+      - type: string
+        format: ipv4
+      - type: string
+        format: ipv6
+    additionalProperties:
+      type: integer
+      format: int32
+    nullable: true
+`, sp)
+
+	src = `package test
+
+type test struct {
+	Float map[float64]string
+}
+`
+	r = scanSource(t, src, 0, "test")
+	_, err = r.Resolve(mustPoint(t, "test"))
+	require.Error(t, err)
+}
+
+func TestExtensions(t *testing.T) {
+	src := `package test
+
+type Item struct {
+	ID   string ` + "`" + `json:"id" oapi:"x-go-name=ItemID,x-internal:true"` + "`" + `
+	Tags []Tag  ` + "`" + `json:"tags" oapi:"tags,x-order=2"` + "`" + `
+}
+
+type Tag struct {
+	Name string ` + "`" + `oapi:"x-go-name=TagName"` + "`" + `
+}
+`
+	r := scanSource(t, src, 0, "Item")
+	r.AddExtension(Extension{
+		Ptr:    mustPoint(t, "Item"),
+		Values: map[string]string{"x-amazon-apigateway-integration": `{"type":"http"}`},
+	})
+
+	sp, err := r.Resolve(mustPoint(t, "Item"))
+	require.NoError(t, err)
+	requireYAML(t, `
+type: object
+properties:
+  id:
+    type: string
+    x-go-name: ItemID
+    x-internal: true
+  tags:
+    type: array
+    items:
+      $ref: go://test#/Tag
+    nullable: true
+    x-order: 2
+x-amazon-apigateway-integration:
+  type: http
+`, sp)
+
+	sp, err = r.Resolve(mustPoint(t, "Tag"))
+	require.NoError(t, err)
+	requireYAML(t, `
+type: object
+properties:
+  Name:
+    type: string
+    x-go-name: TagName
+`, sp)
+}
@@ -0,0 +1,122 @@
+package types
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/buypal/oapi-go/spec"
+	"github.com/buypal/oapi-go/tag"
+	"github.com/pkg/errors"
+)
+
+// hasValues reports if tag holds values which has to be set on schema
+func hasValues(tg tag.Tag) bool {
+	return tg.Example != nil || tg.Default != nil || len(tg.Enum) > 0 ||
+		len(tg.Title) > 0 || len(tg.Description) > 0 || len(tg.Extensions) > 0
+}
+
+// tagValues will set example, default, enum, title, description and
+// extensions given by oapi tag. Values are parsed according to type of schema
+// v, so default:5 is number on integer but string on string schema. Schema
+// v is s unless s refers to other schema.
+func tagValues(s, v *spec.Schema, tg tag.Tag) (err error) {
+	if v == nil {
+		v = s
+	}
+	if len(tg.Title) > 0 {
+		s.Title = tg.Title
+	}
+	if len(tg.Description) > 0 {
+		s.Description = tg.Description
+	}
+	if tg.Example != nil {
+		s.Example, err = parseValue(v, *tg.Example)
+		if err != nil {
+			return errors.Wrap(err, "invalid example")
+		}
+	}
+	if tg.Default != nil {
+		s.Default, err = parseValue(v, *tg.Default)
+		if err != nil {
+			return errors.Wrap(err, "invalid default")
+		}
+	}
+	if len(tg.Enum) > 0 {
+		var vv []spec.Any
+		for _, raw := range tg.Enum {
+			var x spec.Any
+			x, err = parseValue(v, raw)
+			if err != nil {
+				return errors.Wrap(err, "invalid enum")
+			}
+			vv = append(vv, x)
+		}
+		s.Enum = vv
+	}
+	if len(tg.Extensions) > 0 {
+		s.Extensions = extensions(s.Extensions, tg.Extensions)
+	}
+	return
+}
+
+// extensions adds raw values of extensions to ext, values which
+// are not valid json (ie. x-go-name=Foo) are strings.
+func extensions(ext spec.Extensions, raw map[string]string) spec.Extensions {
+	if ext == nil {
+		ext = make(spec.Extensions)
+	}
+	for k, v := range raw {
+		if json.Valid([]byte(v)) {
+			ext[k] = spec.Any(v)
+		} else {
+			ext[k] = spec.NewAny(v)
+		}
+	}
+	return ext
+}
+
+// parseValue will parse raw value of tag as a value of schema, objects
+// and arrays are expected to be written as json. Values of schemas without
+// type (ie. types provided by override) are json or strings.
+func parseValue(s *spec.Schema, raw string) (spec.Any, error) {
+	if raw == "null" && s.Nullable {
+		return spec.Any("null"), nil
+	}
+	switch s.Type {
+	case spec.TypeString:
+		return spec.NewAny(raw), nil
+	case spec.TypeInteger:
+		if v, err := strconv.ParseInt(raw, 10, 64); err == nil {
+			return spec.NewAny(v), nil
+		}
+		// unsigned values might not fit int64
+		if s.Minimum != nil && *s.Minimum >= 0 {
+			if v, err := strconv.ParseUint(raw, 10, 64); err == nil {
+				return spec.NewAny(v), nil
+			}
+		}
+		return nil, errors.Errorf("%q is not an integer", raw)
+	case spec.TypeNumber:
+		v, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, errors.Errorf("%q is not a number", raw)
+		}
+		return spec.NewAny(v), nil
+	case spec.TypeBoolean:
+		v, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, errors.Errorf("%q is not a boolean", raw)
+		}
+		return spec.NewAny(v), nil
+	case spec.TypeObject, spec.TypeArray:
+		if !json.Valid([]byte(raw)) {
+			return nil, errors.Errorf("%q is not a valid json", raw)
+		}
+		return spec.Any(raw), nil
+	default:
+		if !json.Valid([]byte(raw)) {
+			return spec.NewAny(raw), nil
+		}
+		return spec.Any(raw), nil
+	}
+}
@@ -0,0 +1,127 @@
+package types
+
+import (
+	"go/types"
+	"strings"
+
+	"github.com/buypal/oapi-go/spec"
+	"github.com/buypal/oapi-go/tag"
+	"github.com/pkg/errors"
+)
+
+// isXMLName reports if field holds name of xml element, same as
+// encoding/xml does it is field XMLName of type xml.Name.
+func isXMLName(f *types.Var) bool {
+	if f.Name() != "XMLName" {
+		return false
+	}
+	named, ok := types.Unalias(f.Type()).(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return false
+	}
+	return named.Obj().Pkg().Path() == "encoding/xml" && named.Obj().Name() == "Name"
+}
+
+// describesXML reports if struct fields describe xml representation,
+// ie. some of them has xml tag or struct names its element.
+func describesXML(fields []structField) bool {
+	for _, x := range fields {
+		if x.tag.XML != nil || isXMLName(x.field) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasXML reports if xml representation of property has to be described,
+// encoding/xml names elements by go field names unless tag names them.
+// Fields without xml tag are described only in structs describing xml.
+func hasXML(tg tag.Tag, field, name string, describes bool) bool {
+	if tg.XML == nil {
+		return describes && field != name
+	}
+	x := tg.XML
+	switch {
+	case x.Ignore, x.InnerXML:
+		return false
+	case x.Attr, x.CharData, len(x.Parents) > 0, len(x.Namespace) > 0:
+		return true
+	case len(x.Name) > 0:
+		return x.Name != name
+	}
+	return field != name
+}
+
+// structXML returns xml object of struct, name of element
+// is given by tag of XMLName field.
+func structXML(t *types.Struct) *spec.XML {
+	for i := 0; i < t.NumFields(); i++ {
+		if !isXMLName(t.Field(i)) {
+			continue
+		}
+		tg, err := tag.Parse(t.Tag(i))
+		if err != nil || tg.XML == nil || tg.XML.Ignore || len(tg.XML.Name) == 0 {
+			return nil
+		}
+		return &spec.XML{
+			Name:      tg.XML.Name,
+			Namespace: tg.XML.Namespace,
+		}
+	}
+	return nil
+}
+
+// xml2schema will describe xml representation of property given by xml
+// tag, elements without name in tag are named by go field. Element chains
+// like a>b are supported only for arrays, those are wrapped in element a,
+// while items are named b.
+func xml2schema(s *spec.Schema, tg tag.Tag, field, name string) error {
+	x := tg.XML
+	if x == nil {
+		x = &tag.XML{}
+	}
+	if x.Ignore || x.InnerXML {
+		return nil
+	}
+	elem := x.Name
+	if len(elem) == 0 {
+		elem = field
+	}
+
+	switch {
+	case x.Attr:
+		s.XML = &spec.XML{Name: elem, Namespace: x.Namespace, Attribute: true}
+		return nil
+	case x.CharData:
+		s.XML = &spec.XML{Text: true}
+		return nil
+	}
+
+	array := s.Type == spec.TypeArray && s.Items != nil
+	if len(x.Parents) > 1 || (len(x.Parents) > 0 && !array) {
+		chain := strings.Join(append(append([]string{}, x.Parents...), elem), ">")
+		return errors.Errorf("xml element chain %q is supported only as wrapper of array", chain)
+	}
+
+	if !array {
+		if elem != name || len(x.Namespace) > 0 {
+			s.XML = &spec.XML{Name: elem, Namespace: x.Namespace}
+		}
+		return nil
+	}
+
+	// items of array are named by property unless named otherwise
+	if elem != name || len(x.Namespace) > 0 {
+		if s.Items.Ref != nil {
+			s.Items = &spec.Schema{AllOf: []*spec.Schema{s.Items}}
+		}
+		s.Items.XML = &spec.XML{Name: elem, Namespace: x.Namespace}
+	}
+	if len(x.Parents) > 0 {
+		s.XML = &spec.XML{
+			Name:    x.Parents[0],
+			Wrapped: true,
+		}
+	}
+	return nil
+}
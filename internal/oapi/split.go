@@ -0,0 +1,169 @@
+package oapi
+
+import (
+	"strings"
+
+	"github.com/buypal/oapi-go/internal/container"
+	"github.com/pkg/errors"
+)
+
+const schemasPrefix = "#/components/schemas/"
+
+// variant of schema used either in requests or in responses
+type variant struct {
+	suffix string
+	// properties flagged by drop are not part of variant
+	drop string
+}
+
+var (
+	inputVariant  = variant{suffix: "Input", drop: "readOnly"}
+	outputVariant = variant{suffix: "Output", drop: "writeOnly"}
+)
+
+// SplitReadWrite will create <Name>Input and <Name>Output variants of schema
+// components which have readOnly or writeOnly properties (or refer to such
+// components). Input variant has no readOnly properties and output variant
+// has no writeOnly properties. References in request bodies are updated to
+// point to input variant, references in responses to output variant.
+func SplitReadWrite(cnt container.Container) (err error) {
+	schemas, ok := cnt.Path("components.schemas").Data().(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	split := splitSchemas(schemas)
+	if len(split) == 0 {
+		return
+	}
+
+	for name := range split {
+		for _, v := range []variant{inputVariant, outputVariant} {
+			vname := name + v.suffix
+			if _, ok := schemas[vname]; ok {
+				return errors.Errorf("schema %q can not be split, component %q already exists", name, vname)
+			}
+			var s container.Container
+			s, err = container.Make(schemas[name])
+			if err != nil {
+				return
+			}
+			dropProperties(s.Data(), v.drop)
+			rewriteRefs(s.Data(), split, v.suffix)
+			err = cnt.SetP(container.SliceToDotPath([]string{"components", "schemas", vname}), s)
+			if err != nil {
+				return
+			}
+		}
+	}
+
+	paths, _ := cnt.Path("paths").Data().(map[string]interface{})
+	for _, item := range paths {
+		ops, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, op := range ops {
+			o, ok := op.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			rewriteRefs(o["requestBody"], split, inputVariant.suffix)
+			rewriteRefs(o["responses"], split, outputVariant.suffix)
+		}
+	}
+
+	rewriteRefs(cnt.Path("components.requestBodies").Data(), split, inputVariant.suffix)
+	rewriteRefs(cnt.Path("components.responses").Data(), split, outputVariant.suffix)
+	return
+}
+
+// splitSchemas returns names of schemas which have readOnly or writeOnly
+// properties, including schemas referring to those.
+func splitSchemas(schemas map[string]interface{}) map[string]bool {
+	split := make(map[string]bool)
+	for name, s := range schemas {
+		walkData(s, func(m map[string]interface{}) {
+			if m["readOnly"] == true || m["writeOnly"] == true {
+				split[name] = true
+			}
+		})
+	}
+	for changed := true; changed; {
+		changed = false
+		for name, s := range schemas {
+			if split[name] {
+				continue
+			}
+			walkData(s, func(m map[string]interface{}) {
+				if ref, ok := schemaRef(m); ok && split[ref] {
+					split[name] = true
+					changed = true
+				}
+			})
+		}
+	}
+	return split
+}
+
+// dropProperties removes properties flagged by given flag
+// and removes them from list of required properties.
+func dropProperties(v interface{}, flag string) {
+	walkData(v, func(m map[string]interface{}) {
+		props, ok := m["properties"].(map[string]interface{})
+		if !ok {
+			return
+		}
+		for k, p := range props {
+			if pm, ok := p.(map[string]interface{}); !ok || pm[flag] != true {
+				continue
+			}
+			delete(props, k)
+			req, _ := m["required"].([]interface{})
+			for i, r := range req {
+				if r == k {
+					req = append(req[:i], req[i+1:]...)
+					break
+				}
+			}
+			if len(req) > 0 {
+				m["required"] = req
+			} else {
+				delete(m, "required")
+			}
+		}
+	})
+}
+
+// rewriteRefs updates references of split schemas to point to variant
+func rewriteRefs(v interface{}, split map[string]bool, suffix string) {
+	walkData(v, func(m map[string]interface{}) {
+		if ref, ok := schemaRef(m); ok && split[ref] {
+			m["$ref"] = schemasPrefix + ref + suffix
+		}
+	})
+}
+
+// schemaRef returns name of schema component referenced by $ref
+func schemaRef(m map[string]interface{}) (string, bool) {
+	ref, ok := m["$ref"].(string)
+	if !ok || !strings.HasPrefix(ref, schemasPrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(ref, schemasPrefix), true
+}
+
+// walkData calls fn for every object nested in v
+func walkData(v interface{}, fn func(map[string]interface{})) {
+	switch x := v.(type) {
+	case map[string]interface{}:
+		fn(x)
+		for _, y := range x {
+			walkData(y, fn)
+		}
+	case []interface{}:
+		for _, y := range x {
+			walkData(y, fn)
+		}
+	}
+}
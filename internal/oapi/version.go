@@ -0,0 +1,296 @@
+package oapi
+
+import (
+	"strings"
+
+	"github.com/buypal/oapi-go/internal/container"
+	"github.com/pkg/errors"
+)
+
+// keys of operations in path item
+var operationKeys = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// subschemas of schema by keyword
+var (
+	schemaKeywords     = []string{"items", "additionalProperties", "not", "propertyNames", propertyNamesExtension}
+	schemaListKeywords = []string{"allOf", "oneOf", "anyOf"}
+	schemaMapKeywords  = []string{"properties", "$defs"}
+)
+
+// SetVersion will set version of openapi document. Schemas are described
+// using OpenAPI 3.0 constructs, for version 3.1 these are converted
+// to their JSON Schema 2020-12 equivalents. Constructs of 3.1 which
+// have 3.0 equivalent are converted back for version 3.0, ie. null
+// type becomes nullable and numeric exclusive bounds become flags.
+func SetVersion(cnt container.Container, version string) (err error) {
+	var convert func(map[string]interface{}) error
+	switch {
+	case strings.HasPrefix(version+".", "3.0."):
+		convert = schema30
+		if !cnt.Path("webhooks").IsNil() {
+			return errors.Errorf("webhooks are not supported by openapi %s", version)
+		}
+	case strings.HasPrefix(version+".", "3.1."):
+		convert = schema31
+	default:
+		return errors.Errorf("unsupported openapi version %q", version)
+	}
+
+	fn := func(m map[string]interface{}) {
+		if err == nil {
+			err = convert(m)
+		}
+	}
+
+	walkDocument(cnt.Data(), func(s interface{}) {
+		walkSchema(s, fn)
+	})
+	if err != nil {
+		return
+	}
+
+	return cnt.SetP("openapi", version)
+}
+
+// propertyNamesExtension holds propertyNames in 3.0 schemas, where
+// keyword is not allowed, ie. for keys of maps described by scanner
+const propertyNamesExtension = "x-propertyNames"
+
+// schema31 converts 3.0 schema to 3.1 schema
+func schema31(m map[string]interface{}) error {
+	if names, ok := m[propertyNamesExtension]; ok {
+		delete(m, propertyNamesExtension)
+		m["propertyNames"] = names
+	}
+
+	if m["nullable"] == true {
+		delete(m, "nullable")
+		switch t := m["type"].(type) {
+		case string:
+			m["type"] = []interface{}{t, "null"}
+			if enum, ok := m["enum"].([]interface{}); ok {
+				m["enum"] = append(enum, nil)
+			}
+		case nil:
+			// schemas without type (ie. references) are wrapped
+			inner := make(map[string]interface{})
+			for k, v := range m {
+				inner[k] = v
+				delete(m, k)
+			}
+			m["anyOf"] = []interface{}{inner, map[string]interface{}{"type": "null"}}
+		}
+	}
+	delete(m, "nullable")
+
+	// exclusive bounds are numbers instead of modifiers of bounds
+	for bound, exclusive := range map[string]string{
+		"minimum": "exclusiveMinimum",
+		"maximum": "exclusiveMaximum",
+	} {
+		b, ok := m[exclusive].(bool)
+		if !ok {
+			continue
+		}
+		delete(m, exclusive)
+		if v, ok := m[bound]; ok && b {
+			m[exclusive] = v
+			delete(m, bound)
+		}
+	}
+
+	if ex, ok := m["example"]; ok {
+		if _, ok := m["examples"]; !ok {
+			m["examples"] = []interface{}{ex}
+		}
+		delete(m, "example")
+	}
+	return nil
+}
+
+// schema30 converts 3.1 constructs of schema to 3.0 where possible
+func schema30(m map[string]interface{}) error {
+	if _, ok := m["$defs"]; ok {
+		return errors.New("$defs are not supported by openapi 3.0")
+	}
+	if names, ok := m["propertyNames"]; ok {
+		delete(m, "propertyNames")
+		m[propertyNamesExtension] = names
+	}
+
+	// null type becomes nullable
+	if tt, ok := m["type"].([]interface{}); ok {
+		var types []interface{}
+		for _, t := range tt {
+			if t == "null" {
+				m["nullable"] = true
+			} else {
+				types = append(types, t)
+			}
+		}
+		if len(types) != 1 {
+			return errors.Errorf("type %v is not supported by openapi 3.0", tt)
+		}
+		m["type"] = types[0]
+	}
+	if alt, ok := m["anyOf"].([]interface{}); ok && len(alt) == 2 && isNullSchema(alt[1]) {
+		if inner, ok := alt[0].(map[string]interface{}); ok {
+			delete(m, "anyOf")
+			for k, v := range inner {
+				m[k] = v
+			}
+			m["nullable"] = true
+		}
+	}
+
+	// exclusive bounds are modifiers of bounds
+	for bound, exclusive := range map[string]string{
+		"minimum": "exclusiveMinimum",
+		"maximum": "exclusiveMaximum",
+	} {
+		v, ok := m[exclusive]
+		if _, isFlag := v.(bool); !ok || isFlag {
+			continue
+		}
+		m[bound] = v
+		m[exclusive] = true
+	}
+
+	if c, ok := m["const"]; ok {
+		m["enum"] = []interface{}{c}
+		delete(m, "const")
+	}
+	if ex, ok := m["examples"].([]interface{}); ok {
+		if _, ok := m["example"]; !ok && len(ex) > 0 {
+			m["example"] = ex[0]
+		}
+		delete(m, "examples")
+	}
+	return nil
+}
+
+// isNullSchema reports if schema is {type: null}
+func isNullSchema(v interface{}) bool {
+	m, ok := v.(map[string]interface{})
+	return ok && len(m) == 1 && m["type"] == "null"
+}
+
+// walkDocument calls fn for schemas of components and for schemas
+// of parameters, headers and media types in document
+func walkDocument(v interface{}, fn func(interface{})) {
+	doc, _ := v.(map[string]interface{})
+	components, _ := doc["components"].(map[string]interface{})
+	for _, s := range mapOf(components["schemas"]) {
+		fn(s)
+	}
+	for _, p := range mapOf(components["parameters"]) {
+		walkParameter(p, fn)
+	}
+	for _, h := range mapOf(components["headers"]) {
+		walkParameter(h, fn)
+	}
+	for _, r := range mapOf(components["responses"]) {
+		walkResponse(r, fn)
+	}
+	for _, b := range mapOf(components["requestBodies"]) {
+		walkContent(b, fn)
+	}
+	for _, c := range mapOf(components["callbacks"]) {
+		walkPaths(c, fn)
+	}
+	for _, i := range mapOf(components["pathItems"]) {
+		walkPathItem(i, fn)
+	}
+	walkPaths(doc["paths"], fn)
+	walkPaths(doc["webhooks"], fn)
+}
+
+// walkPaths walks path items of paths, callbacks or webhooks
+func walkPaths(v interface{}, fn func(interface{})) {
+	for _, i := range mapOf(v) {
+		walkPathItem(i, fn)
+	}
+}
+
+func walkPathItem(v interface{}, fn func(interface{})) {
+	item := mapOf(v)
+	for _, p := range listOf(item["parameters"]) {
+		walkParameter(p, fn)
+	}
+	for _, k := range operationKeys {
+		op := mapOf(item[k])
+		for _, p := range listOf(op["parameters"]) {
+			walkParameter(p, fn)
+		}
+		walkContent(op["requestBody"], fn)
+		for _, r := range mapOf(op["responses"]) {
+			walkResponse(r, fn)
+		}
+		for _, c := range mapOf(op["callbacks"]) {
+			walkPaths(c, fn)
+		}
+	}
+}
+
+func walkResponse(v interface{}, fn func(interface{})) {
+	for _, h := range mapOf(mapOf(v)["headers"]) {
+		walkParameter(h, fn)
+	}
+	walkContent(v, fn)
+}
+
+// walkParameter walks parameter or header, both have either schema or content
+func walkParameter(v interface{}, fn func(interface{})) {
+	if s, ok := mapOf(v)["schema"]; ok {
+		fn(s)
+	}
+	walkContent(v, fn)
+}
+
+// walkContent walks media types of object having content
+func walkContent(v interface{}, fn func(interface{})) {
+	for _, mt := range mapOf(mapOf(v)["content"]) {
+		if s, ok := mapOf(mt)["schema"]; ok {
+			fn(s)
+		}
+		for _, e := range mapOf(mapOf(mt)["encoding"]) {
+			for _, h := range mapOf(mapOf(e)["headers"]) {
+				walkParameter(h, fn)
+			}
+		}
+	}
+}
+
+func mapOf(v interface{}) map[string]interface{} {
+	m, _ := v.(map[string]interface{})
+	return m
+}
+
+func listOf(v interface{}) []interface{} {
+	l, _ := v.([]interface{})
+	return l
+}
+
+// walkSchema calls fn for schema and all its subschemas
+func walkSchema(v interface{}, fn func(map[string]interface{})) {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return
+	}
+	fn(m)
+	for _, k := range schemaKeywords {
+		walkSchema(m[k], fn)
+	}
+	for _, k := range schemaListKeywords {
+		ss, _ := m[k].([]interface{})
+		for _, s := range ss {
+			walkSchema(s, fn)
+		}
+	}
+	for _, k := range schemaMapKeywords {
+		ss, _ := m[k].(map[string]interface{})
+		for _, s := range ss {
+			walkSchema(s, fn)
+		}
+	}
+}
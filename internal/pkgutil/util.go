@@ -1,9 +1,12 @@
 package pkgutil
 
 import (
+	"go/token"
 	"sort"
+	"strconv"
 	"strings"
 
+	"github.com/buypal/oapi-go/internal/diag"
 	"github.com/pkg/errors"
 	"golang.org/x/tools/go/packages"
 )
@@ -26,9 +29,10 @@ type Scanner interface {
 	Scan(*packages.Package) error
 }
 
-// Scan visits all the packages in the import graph
+// Scan visits all the packages in the import graph, errors
+// of all packages are aggregated into diag.List.
 func Scan(pkgs []*packages.Package, fn Scanner) (err error) {
-	var errs []error
+	var errs diag.List
 
 	seen := make(map[*packages.Package]bool)
 	var visit func(*packages.Package)
@@ -40,7 +44,7 @@ func Scan(pkgs []*packages.Package, fn Scanner) (err error) {
 
 		// First collect and check parsing errors
 		for _, err := range pkg.Errors {
-			errs = append(errs, errors.Wrapf(err, "failed to parse pkg"))
+			errs = append(errs, diag.At(position(err.Pos), errors.New("failed to parse pkg: "+err.Msg)))
 		}
 
 		paths := make([]string, 0, len(pkg.Imports))
@@ -63,7 +67,7 @@ func Scan(pkgs []*packages.Package, fn Scanner) (err error) {
 	}
 
 	if len(errs) > 0 {
-		return newSingleError(errs)
+		return errs
 	}
 
 	return nil
@@ -75,10 +79,19 @@ func IsStdLibPkg(pkg *packages.Package) bool {
 	return ok
 }
 
-func newSingleError(errs []error) error {
-	var msgs []string
-	for _, m := range errs {
-		msgs = append(msgs, "\t- "+m.Error())
+// position parses position of package error (file:line:col)
+func position(pos string) (p token.Position) {
+	ss := strings.Split(pos, ":")
+	for i := len(ss) - 1; i > 0 && i >= len(ss)-2; i-- {
+		n, err := strconv.Atoi(ss[i])
+		if err != nil {
+			break
+		}
+		p.Line, p.Column = n, p.Line
+		ss = ss[:i]
+	}
+	if pos != "-" {
+		p.Filename = strings.Join(ss, ":")
 	}
-	return errors.Errorf("failed to scan: \n%s", strings.Join(msgs, "\n"))
+	return
 }
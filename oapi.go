@@ -3,18 +3,20 @@ package oapi
 import (
 	"context"
 	"encoding/json"
+	"go/token"
 	"os"
 
 	"github.com/buypal/oapi-go/internal/container"
+	"github.com/buypal/oapi-go/internal/diag"
 	"github.com/buypal/oapi-go/internal/logging"
 	"github.com/buypal/oapi-go/internal/oapi"
 	"github.com/buypal/oapi-go/internal/oapi/resolver"
 	"github.com/buypal/oapi-go/internal/oapi/scan/cmds"
 	"github.com/buypal/oapi-go/internal/oapi/scan/specs"
 	"github.com/buypal/oapi-go/internal/oapi/scan/types"
-	"github.com/buypal/oapi-go/internal/oapi/spec"
 	"github.com/buypal/oapi-go/internal/pkgutil"
-	"github.com/buypal/oapi-go/internal/pointer"
+	"github.com/buypal/oapi-go/pointer"
+	"github.com/buypal/oapi-go/spec"
 	"github.com/pkg/errors"
 	"golang.org/x/tools/go/packages"
 )
@@ -26,6 +28,7 @@ var order = []string{
 	"info",
 	"components",
 	"paths",
+	"webhooks",
 }
 
 // OAPI specification holds valid openapi specification.
@@ -34,10 +37,15 @@ type OAPI struct {
 	c container.Container
 }
 
+// Spec returns typed view of specification.
+func (x OAPI) Spec() spec.OpenAPI {
+	return x.o
+}
+
 // newOAPI creates new specs from container
 func newOAPI(c container.Container) (x OAPI, err error) {
 	x = OAPI{c: c}
-	err = json.Unmarshal(c.Bytes(), &x.o.OpenAPI)
+	err = json.Unmarshal(c.Bytes(), &x.o)
 	return x, err
 }
 
@@ -46,8 +54,16 @@ type Options struct {
 	dir      *string
 	log      logging.Printer
 	override map[string]spec.Schema
+	types    map[string]spec.Schema
 	defops   map[string]spec.Operation
 	root     spec.OpenAPI
+	mode     types.Mode
+	split    bool
+	version  string
+	naming   resolver.Naming
+	auto     bool
+	remote   resolver.HTTPOptions
+	schemes  map[string]ResolverFunc
 }
 
 func (opts *Options) path() (dir string, err error) {
@@ -94,6 +110,16 @@ func WithOverride(or map[string]spec.Schema) Option {
 	}
 }
 
+// WithTypes will register schemas of go types given by their names,
+// ie. github.com/google/uuid.UUID. Unlike overrides these are used
+// whenever type is scanned, built-in schemas can be replaced this way.
+func WithTypes(tt map[string]spec.Schema) Option {
+	return func(r *Options) error {
+		r.types = tt
+		return nil
+	}
+}
+
 // WithDefOps is shorthant for with default operations.
 func WithDefOps(defops map[string]spec.Operation) Option {
 	return func(r *Options) error {
@@ -111,6 +137,135 @@ func WithRootSchema(oapi spec.OpenAPI) Option {
 	}
 }
 
+// WithEnumStringer will make integer enums with String() method
+// to be documented with values returned by String() instead of integers.
+// String() has to be generated by stringer tool, switch over constants
+// returning literals or return element of map, array or slice literal.
+func WithEnumStringer(enabled bool) Option {
+	return func(r *Options) error {
+		r.setMode(types.EnumStringer, enabled)
+		return nil
+	}
+}
+
+// WithInferRequired will make every field which is neither omitempty
+// nor pointer required. Fields can opt out by oapi:"required=false" tag.
+func WithInferRequired(enabled bool) Option {
+	return func(r *Options) error {
+		r.setMode(types.InferRequired, enabled)
+		return nil
+	}
+}
+
+// WithComposeEmbedded will describe structs embedding exported types
+// as allOf composition, so inheritance is kept in schemas. Embedded fields
+// can opt in or out by oapi:",compose" or oapi:",compose:false" tag.
+func WithComposeEmbedded(enabled bool) Option {
+	return func(r *Options) error {
+		r.setMode(types.ComposeEmbedded, enabled)
+		return nil
+	}
+}
+
+// WithProtoJSON will describe types generated by protoc-gen-go as they are
+// encoded by protojson. Field names are taken from protobuf tag, oneof
+// fields are described as oneOf and well known types by their json form.
+func WithProtoJSON(enabled bool) Option {
+	return func(r *Options) error {
+		r.setMode(types.ProtoJSON, enabled)
+		return nil
+	}
+}
+
+// WithOpenAPI sets version of produced openapi document, ie. 3.0.3
+// or 3.1.0. Schemas are converted to JSON Schema 2020-12 for 3.1.
+// By default version given by openapi files is kept.
+func WithOpenAPI(version string) Option {
+	return func(r *Options) error {
+		r.version = version
+		return nil
+	}
+}
+
+// Error is error of scan with position in go or spec file and chain
+// of pointers which led to it.
+type Error = diag.Error
+
+// ErrorList aggregates errors of scanned packages.
+type ErrorList = diag.List
+
+// Naming configures how exported components are named.
+type Naming = resolver.Naming
+
+// RemoteOptions configures resolving of http(s) references.
+type RemoteOptions = resolver.HTTPOptions
+
+// WithNaming sets how exported components are named, by default
+// they are named by bare type names and collisions are errors.
+func WithNaming(n Naming) Option {
+	return func(r *Options) error {
+		r.naming = n
+		return nil
+	}
+}
+
+// WithAutoExport will export named structs which are referenced more
+// than once or which are recursive as schema components, instead
+// of inlining them.
+func WithAutoExport(enabled bool) Option {
+	return func(r *Options) error {
+		r.auto = enabled
+		return nil
+	}
+}
+
+// WithRemote configures resolving of http(s) references, fetched
+// documents can be cached on disk, served offline and pinned by lock file.
+func WithRemote(o RemoteOptions) Option {
+	return func(r *Options) error {
+		r.remote = o
+		return nil
+	}
+}
+
+// ResolverFunc resolves pointer into openapi entity, ie. spec.Schema
+// or spec.Response. References in resolved entity are resolved as well.
+type ResolverFunc func(ctx context.Context, ptr pointer.Pointer) (spec.Entiter, error)
+
+// WithResolver registers resolver of pointers with given scheme, ie. db for
+// db://users#/User. Built-in schemes (go, file, http, https) can be
+// replaced this way, overrides still take precedence.
+func WithResolver(scheme string, fn ResolverFunc) Option {
+	return func(r *Options) error {
+		if len(scheme) == 0 || fn == nil {
+			return errors.New("resolver requires scheme and function")
+		}
+		if r.schemes == nil {
+			r.schemes = make(map[string]ResolverFunc)
+		}
+		r.schemes[scheme] = fn
+		return nil
+	}
+}
+
+// WithSplitReadWrite will split schema components having readOnly or
+// writeOnly properties into <Name>Input and <Name>Output variants. Request
+// bodies refer to input variants and responses to output variants.
+func WithSplitReadWrite(enabled bool) Option {
+	return func(r *Options) error {
+		r.split = enabled
+		return nil
+	}
+}
+
+func (opts *Options) setMode(mode types.Mode, enabled bool) {
+	if enabled {
+		opts.mode |= mode
+	} else {
+		opts.mode &^= mode
+	}
+}
+
 // Scan will scan all types in given directory (by deault cwd), and merge all
 // openapi files into single document returned as OAPI.
 func Scan(ctx context.Context, options ...Option) (s OAPI, err error) {
@@ -152,6 +307,15 @@ func Scan(ctx context.Context, options ...Option) (s OAPI, err error) {
 		return
 	}
 
+	// types mapped by commands, overrides from options take precedence
+	overrides, err := cmdsScanner.Overrides()
+	if err != nil {
+		return
+	}
+	for k, v := range opts.override {
+		overrides[k] = v
+	}
+
 	// Now we scan for yaml files specifications
 	specsScanner := specs.NewScanner()
 	err = pkgutil.Scan(pkgs, specsScanner)
@@ -164,35 +328,121 @@ func Scan(ctx context.Context, options ...Option) (s OAPI, err error) {
 		return
 	}
 
+	// operations declared by commands
+	err = cmdsScanner.MergePaths(c)
+	if err != nil {
+		return
+	}
+
 	pp := make(pointer.Pointers)
 	pp = pp.Merge(cmdsScanner.Commands.Pointers())
 	pp = pp.Merge(specsScanner.Pointers)
 
 	// collect and handle types
-	tps := types.NewScanner(pp)
+	tps := types.NewScanner(pp, opts.mode)
+	for _, e := range exports {
+		if e.IsScheme() {
+			tps.AddExport(e.Pointer)
+		}
+	}
+	for name, t := range opts.types {
+		err = tps.AddType(name, t)
+		if err != nil {
+			return
+		}
+	}
+	for _, o := range cmdsScanner.OneOfs() {
+		tps.AddOneOf(o)
+	}
+	extensions, err := cmdsScanner.Extensions()
+	if err != nil {
+		return
+	}
+	for _, e := range extensions {
+		tps.AddExtension(e)
+	}
 	err = pkgutil.Scan(pkgs, tps)
 	if err != nil {
 		return
 	}
 
+	// discovered variants of polymorphic interfaces
+	variants, err := tps.ExportedComponents()
+	if err != nil {
+		return
+	}
+	for _, v := range variants {
+		if _, ok := exports.Get(v.Pointer); !ok {
+			exports = append(exports, v)
+		}
+	}
+
 	err = oapi.MergeWithRoot(opts.root, c)
 	if err != nil {
 		return
 	}
 
-	cnt, err := resolver.Resolve(c, exports, func(ptr pointer.Pointer) (e spec.Entiter, err error) {
-		if ovrd, ok := opts.override[ptr.String()]; ok {
+	files := resolver.FileFn()
+	remote := resolver.HTTPFn(ctx, opts.remote)
+	resolve := func(ptr pointer.Pointer) (e spec.Entiter, err error) {
+		if ovrd, ok := overrides[ptr.String()]; ok {
 			return ovrd, nil
 		}
+		if fn, ok := opts.schemes[ptr.Scheme]; ok {
+			return fn(ctx, ptr)
+		}
 		switch ptr.Scheme {
 		case "go":
 			e, err = tps.Resolve(ptr)
+		case "file":
+			e, err = files(ptr)
+		case "http", "https":
+			e, err = remote(ptr)
 		default:
-			err = errors.New("unknown protocol to resolve")
+			err = errors.Errorf("unknown protocol %q to resolve", ptr.Scheme)
 		}
 		return
-	})
+	}
+
+	// pointers are resolved once, results of discovery are reused
+	cached := resolver.Cached(resolve)
+
+	// reused and recursive types are exported under name of type
+	if opts.auto {
+		var reused []pointer.Pointer
+		reused, err = resolver.Reused(c, cached)
+		if err != nil {
+			err = refPosition(err, specsScanner.Refs)
+			return
+		}
+		for _, p := range reused {
+			if _, ok := exports.Get(p); ok {
+				continue
+			}
+			last, _ := p.Fragment.Last()
+			exports = append(exports, resolver.Pointer{
+				Pointer: p,
+				Entity: resolver.Entity{
+					Entity: spec.SchemaKind,
+					Name:   types.ComponentName(last),
+				},
+			})
+			tps.AddExport(p)
+			// embedded types are composed if they are exported
+			if opts.mode&types.ComposeEmbedded != 0 {
+				cached = resolver.Cached(resolve)
+			}
+		}
+	}
+
+	exports, err = opts.naming.Apply(exports)
+	if err != nil {
+		return
+	}
+
+	cnt, err := resolver.Resolve(c, exports, cached)
 	if err != nil {
+		err = refPosition(err, specsScanner.Refs)
 		return
 	}
 
@@ -201,9 +451,41 @@ func Scan(ctx context.Context, options ...Option) (s OAPI, err error) {
 		return
 	}
 
+	if opts.split {
+		err = oapi.SplitReadWrite(cnt)
+		if err != nil {
+			return
+		}
+	}
+
+	version := opts.version
+	if len(version) == 0 {
+		version, _ = cnt.Path("openapi").Data().(string)
+	}
+	if len(version) > 0 {
+		err = oapi.SetVersion(cnt, version)
+		if err != nil {
+			return
+		}
+	}
+
 	return newOAPI(cnt)
 }
 
+// refPosition sets position of reference in spec file which started
+// chain of pointers of error, unless error has position already.
+func refPosition(err error, refs map[string]token.Position) error {
+	chain := diag.ChainOf(err)
+	if len(chain) == 0 {
+		return err
+	}
+	pos, ok := refs[chain[0].String()]
+	if !ok {
+		return err
+	}
+	return diag.At(pos, err)
+}
+
 // Format will format given specs into given format.
 func Format(f string, oapi OAPI) (data []byte, err error) {
 	sorter := container.SortMapMarhsaler(order)
@@ -0,0 +1,116 @@
+package oapi
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/buypal/oapi-go/internal/diag"
+	"github.com/buypal/oapi-go/pointer"
+	"github.com/buypal/oapi-go/spec"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanOpenAPI31(t *testing.T) {
+	s, err := Scan(context.Background(), WithDir("testdata/nullable"), WithOpenAPI("3.1.0"))
+	require.NoError(t, err)
+
+	o := s.Spec()
+	require.Equal(t, "3.1.0", o.OpenAPI)
+	item := o.Components.Schemas["Item"]
+	require.NotNil(t, item)
+	require.Equal(t, []spec.OAPIType{spec.TypeString, "null"}, item.Properties["name"].Types)
+	require.False(t, item.Properties["name"].Nullable)
+	require.Equal(t, spec.TypeInteger, item.Properties["count"].Type)
+}
+
+func TestScanOperationPath(t *testing.T) {
+	s, err := Scan(context.Background(), WithDir("testdata/operation"))
+	require.NoError(t, err)
+
+	o := s.Spec()
+	require.Len(t, o.Paths, 1)
+	item := o.Paths["/v1/files/{name}.json"]
+	require.NotNil(t, item)
+	require.NotNil(t, item.Get)
+	schema := item.Get.Responses["200"].Content["application/json"].Schema
+	require.Nil(t, schema.Ref)
+	require.Equal(t, spec.TypeObject, schema.Type)
+}
+
+func TestScanWithResolver(t *testing.T) {
+	var calls []string
+	fn := func(ctx context.Context, p pointer.Pointer) (spec.Entiter, error) {
+		calls = append(calls, p.String())
+		return spec.Schema{Type: spec.TypeObject, Title: p.Scheme}, nil
+	}
+
+	// custom scheme and built-in https scheme replaced
+	s, err := Scan(context.Background(),
+		WithDir("testdata/resolver"),
+		WithResolver("db", fn),
+		WithResolver("https", fn),
+	)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{
+		"db://users#/User",
+		"https://example.com/errors.yaml#/Error",
+	}, calls)
+
+	responses := s.Spec().Paths["/users"].Get.Responses
+	require.Equal(t, "db", responses["200"].Content["application/json"].Schema.Title)
+	require.Equal(t, "https", responses["default"].Content["application/json"].Schema.Title)
+
+	_, err = Scan(context.Background(), WithDir("testdata/resolver"), WithResolver("", fn))
+	require.Error(t, err)
+	_, err = Scan(context.Background(), WithDir("testdata/resolver"), WithResolver("db", nil))
+	require.Error(t, err)
+}
+
+func TestScanAutoExport(t *testing.T) {
+	var calls int
+	fn := func(ctx context.Context, p pointer.Pointer) (spec.Entiter, error) {
+		calls++
+		return spec.Schema{Type: spec.TypeObject}, nil
+	}
+
+	s, err := Scan(context.Background(),
+		WithDir("testdata/autoexport"),
+		WithAutoExport(true),
+		WithResolver("db", fn),
+	)
+	require.NoError(t, err)
+	// pointers discovered by auto export are not resolved again
+	require.Equal(t, 1, calls)
+
+	o := s.Spec()
+	require.Contains(t, o.Components.Schemas, "Address")
+	require.Contains(t, o.Components.Schemas, "Category")
+	require.NotContains(t, o.Components.Schemas, "Customer")
+
+	customer := o.Paths["/customers"].Get.Responses["200"].Content["application/json"].Schema
+	require.Equal(t, "#/components/schemas/Address", customer.Properties["billing"].Ref.String())
+	require.Equal(t, "#/components/schemas/Address", customer.Properties["shipping"].Ref.String())
+
+	category := o.Components.Schemas["Category"]
+	require.Equal(t, "#/components/schemas/Category", category.Properties["children"].Items.Ref.String())
+}
+
+func TestScanErrorPosition(t *testing.T) {
+	// types are reported at position of field in go file
+	_, err := Scan(context.Background(), WithDir("testdata/badtype"))
+	require.Error(t, err)
+	pos, ok := diag.PosOf(err)
+	require.True(t, ok)
+	require.Equal(t, "types.go", filepath.Base(pos.Filename))
+	require.Equal(t, 6, pos.Line)
+
+	// references are reported at line of $ref in spec file
+	_, err = Scan(context.Background(), WithDir("testdata/badref"))
+	require.Error(t, err)
+	pos, ok = diag.PosOf(err)
+	require.True(t, ok)
+	require.Equal(t, "oapi.yaml", filepath.Base(pos.Filename))
+	require.Equal(t, 20, pos.Line)
+	require.Contains(t, err.Error(), "oapi.yaml:20")
+}
@@ -13,6 +13,7 @@ package pointer
 import (
 	"fmt"
 	"net/url"
+	"path/filepath"
 	"strconv"
 )
 
@@ -107,6 +108,25 @@ func (p Pointer) IsExternal() bool {
 	return p.Scheme != ""
 }
 
+// IsRelative reports if pointer refers to file by relative
+// path, ie. ./schemas/error.yaml#/Error.
+func (p Pointer) IsRelative() bool {
+	return p.Scheme == "" && p.Path != ""
+}
+
+// InDir returns file pointer, relative path of pointer
+// is resolved against given directory.
+func (p Pointer) InDir(dir string) Pointer {
+	path := p.Path
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+	return Pointer{
+		URL:      url.URL{Scheme: "file", Path: filepath.ToSlash(path)},
+		Fragment: p.Fragment.Clone(),
+	}
+}
+
 type Pointers map[string]Pointer
 
 func NewPointers(pp []Pointer) Pointers {
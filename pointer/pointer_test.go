@@ -0,0 +1,28 @@
+package pointer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPointer(t *testing.T) {
+	doc, err := Parse("go://pointer.com/somthing#/Object")
+	require.NoError(t, err)
+
+	// require.Equal(t, doc.Fragment.Head(), "Object")
+	require.Equal(t, doc.Scheme, "go")
+	require.Equal(t, doc.Path, "/somthing")
+}
+
+func TestInDir(t *testing.T) {
+	p, err := Parse("./schemas/error.yaml#/Error")
+	require.NoError(t, err)
+	require.True(t, p.IsRelative())
+	require.False(t, p.IsExternal())
+	require.Equal(t, "file:///specs/schemas/error.yaml#/Error", p.InDir("/specs").String())
+
+	p, err = Parse("#/components/schemas/Error")
+	require.NoError(t, err)
+	require.False(t, p.IsRelative())
+}
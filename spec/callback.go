@@ -9,6 +9,9 @@ import (
 type Callback struct {
 	Refable  `json:",inline"`
 	Callback map[Expressions]*PathItem
+
+	// Specification extensions (x-*), inlined into object.
+	Extensions Extensions
 }
 
 // MarshalJSON returns m as the JSON encoding of callback or Refable.
@@ -16,7 +19,7 @@ func (s Callback) MarshalJSON() ([]byte, error) {
 	if s.Ref != nil {
 		return json.Marshal(s.Refable)
 	}
-	return json.Marshal(s.Callback)
+	return marshalExtensible(s.Callback, s.Extensions)
 }
 
 // UnmarshalJSON sets callback or Refable to data.
@@ -34,7 +37,28 @@ func (s *Callback) UnmarshalJSON(data []byte) error {
 	if s.Ref != nil {
 		return nil
 	}
-	return json.Unmarshal(data, &s.Callback)
+	var m map[string]json.RawMessage
+	err = json.Unmarshal(data, &m)
+	if err != nil {
+		return err
+	}
+	s.Callback = make(map[Expressions]*PathItem)
+	for k, raw := range m {
+		if IsExtension(k) {
+			if s.Extensions == nil {
+				s.Extensions = make(Extensions)
+			}
+			s.Extensions[k] = Any(raw)
+			continue
+		}
+		var p *PathItem
+		err = json.Unmarshal(raw, &p)
+		if err != nil {
+			return err
+		}
+		s.Callback[Expressions(k)] = p
+	}
+	return nil
 }
 
 // Entity satisfies componenter interface
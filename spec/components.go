@@ -30,6 +30,21 @@ type Components struct {
 
 	// An object to hold reusable Callback Objects.
 	Callbacks map[string]*Callback `json:"callbacks,omitempty"`
+
+	// Specification extensions (x-*), inlined into object.
+	Extensions Extensions `json:"-"`
+}
+
+// MarshalJSON encodes Components with its extensions.
+func (s Components) MarshalJSON() ([]byte, error) {
+	type plain Components
+	return marshalExtensible(plain(s), s.Extensions)
+}
+
+// UnmarshalJSON decodes Components with its extensions.
+func (s *Components) UnmarshalJSON(data []byte) error {
+	type plain Components
+	return unmarshalExtensible(data, (*plain)(s), &s.Extensions)
 }
 
 // NewComponents create a new new components
@@ -11,4 +11,19 @@ type Discriminator struct {
 
 	// An object to hold mappings between payload values and schema names or references.
 	Mapping map[string]string `json:"mapping,omitempty"`
+
+	// Specification extensions (x-*), inlined into object.
+	Extensions Extensions `json:"-"`
+}
+
+// MarshalJSON encodes Discriminator with its extensions.
+func (s Discriminator) MarshalJSON() ([]byte, error) {
+	type plain Discriminator
+	return marshalExtensible(plain(s), s.Extensions)
+}
+
+// UnmarshalJSON decodes Discriminator with its extensions.
+func (s *Discriminator) UnmarshalJSON(data []byte) error {
+	type plain Discriminator
+	return unmarshalExtensible(data, (*plain)(s), &s.Extensions)
 }
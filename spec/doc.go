@@ -0,0 +1,12 @@
+// Package spec contains object model of OpenAPI 3 document. Objects are
+// marshalled to json (or yaml by converting json) as described by
+// specification and can be built using helpers, ie.:
+//
+//	spec.Operation{
+//		Parameters: []*spec.Parameter{spec.QueryParam("limit", &spec.Schema{Type: "integer"})},
+//		Responses:  map[string]*spec.Response{"200": spec.JSONResponse(&spec.Schema{Type: "object"})},
+//	}
+//
+// Documents built this way are accepted by options of package oapi,
+// ie. oapi.WithRootSchema or oapi.WithDefOps.
+package spec
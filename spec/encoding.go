@@ -25,10 +25,25 @@ type Encoding struct {
 	// When style is form, the default value is true.
 	// For all other styles, the default value is false.
 	// This property SHALL be ignored if the request body media type is not application/x-www-form-urlencoded.
-	Explode bool `json:"explode,omitempty"`
+	Explode *bool `json:"explode,omitempty"`
 
 	// Determines whether the parameter value SHOULD allow reserved characters, as defined by RFC3986 :/?#[]@!$&'()*+,;= to be included without percent-encoding.
 	// The default value is false.
 	// This property SHALL be ignored if the request body media type is not application/x-www-form-urlencoded.
 	AllowReserved bool `json:"allowReserved,omitempty"`
+
+	// Specification extensions (x-*), inlined into object.
+	Extensions Extensions `json:"-"`
+}
+
+// MarshalJSON encodes Encoding with its extensions.
+func (s Encoding) MarshalJSON() ([]byte, error) {
+	type plain Encoding
+	return marshalExtensible(plain(s), s.Extensions)
+}
+
+// UnmarshalJSON decodes Encoding with its extensions.
+func (s *Encoding) UnmarshalJSON(data []byte) error {
+	type plain Encoding
+	return unmarshalExtensible(data, (*plain)(s), &s.Extensions)
 }
@@ -3,7 +3,7 @@ package spec
 import (
 	"fmt"
 
-	"github.com/buypal/oapi-go/internal/pointer"
+	"github.com/buypal/oapi-go/pointer"
 )
 
 // Entity reflets kind of component
@@ -51,6 +51,8 @@ func (c Entity) Key() string {
 		return "examples"
 	case RequestBodyKind:
 		return "requestBodies"
+	case HeaderKind:
+		return "headers"
 	case SecuritySchemeKind:
 		return "securitySchemes"
 	case LinkKind:
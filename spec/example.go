@@ -19,6 +19,21 @@ type Example struct {
 	// This provides the capability to reference examples that cannot easily be included in JSON or YAML documents.
 	// The value field and externalValue field are mutually exclusive.
 	ExternalValue string `json:"externalValue,omitempty"`
+
+	// Specification extensions (x-*), inlined into object.
+	Extensions Extensions `json:"-"`
+}
+
+// MarshalJSON encodes Example with its extensions.
+func (s Example) MarshalJSON() ([]byte, error) {
+	type plain Example
+	return marshalExtensible(plain(s), s.Extensions)
+}
+
+// UnmarshalJSON decodes Example with its extensions.
+func (s *Example) UnmarshalJSON(data []byte) error {
+	type plain Example
+	return unmarshalExtensible(data, (*plain)(s), &s.Extensions)
 }
 
 // Entity satisfies componenter interface
@@ -0,0 +1,81 @@
+package spec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ExtensionPrefix is prefix of specification extensions keys
+const ExtensionPrefix = "x-"
+
+// Extensions are specification extensions (x-*) of object,
+// they are inlined into json encoding of object.
+type Extensions map[string]Any
+
+// IsExtension reports if key is key of specification extension
+func IsExtension(key string) bool {
+	return strings.HasPrefix(key, ExtensionPrefix)
+}
+
+// marshalExtensible encodes v as json object with extensions inlined,
+// v is expected to be object without MarshalJSON method.
+func marshalExtensible(v interface{}, ext Extensions) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(ext) == 0 {
+		return data, err
+	}
+	keys := make([]string, 0, len(ext))
+	for k := range ext {
+		if !IsExtension(k) {
+			return nil, fmt.Errorf("spec: extension %q does not start with %q", k, ExtensionPrefix)
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b bytes.Buffer
+	b.Write(data[:len(data)-1])
+	for i, k := range keys {
+		if i > 0 || len(data) > 2 {
+			b.WriteByte(',')
+		}
+		key, _ := json.Marshal(k)
+		val, err := ext[k].MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		b.Write(key)
+		b.WriteByte(':')
+		b.Write(val)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// unmarshalExtensible decodes v from json object and collects
+// its extensions, v is expected to be object without UnmarshalJSON method.
+func unmarshalExtensible(data []byte, v interface{}, ext *Extensions) error {
+	err := json.Unmarshal(data, v)
+	if err != nil {
+		return err
+	}
+	var m map[string]json.RawMessage
+	err = json.Unmarshal(data, &m)
+	if err != nil {
+		return err
+	}
+	*ext = nil
+	for k, raw := range m {
+		if !IsExtension(k) {
+			continue
+		}
+		if *ext == nil {
+			*ext = make(Extensions)
+		}
+		(*ext)[k] = Any(raw)
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package spec
+
+// ExternalDocumentation Allows referencing an external resource for extended documentation.
+type ExternalDocumentation struct {
+
+	// A short description of the target documentation.
+	// CommonMark syntax MAY be used for rich text representation.
+	Description string `json:"description"`
+
+	// REQUIRED.
+	// The URL for the target documentation.
+	// Value MUST be in the format of a URL.
+	URL string `json:"url,omitempty"`
+
+	// Specification extensions (x-*), inlined into object.
+	Extensions Extensions `json:"-"`
+}
+
+// MarshalJSON encodes ExternalDocumentation with its extensions.
+func (s ExternalDocumentation) MarshalJSON() ([]byte, error) {
+	type plain ExternalDocumentation
+	return marshalExtensible(plain(s), s.Extensions)
+}
+
+// UnmarshalJSON decodes ExternalDocumentation with its extensions.
+func (s *ExternalDocumentation) UnmarshalJSON(data []byte) error {
+	type plain ExternalDocumentation
+	return unmarshalExtensible(data, (*plain)(s), &s.Extensions)
+}
@@ -37,7 +37,7 @@ type Header struct {
 	// For other types of parameters this property has no effect.
 	// When style is form, the default value is true.
 	// For all other styles, the default value is false.
-	Explode bool `json:"explode"`
+	Explode *bool `json:"explode,omitempty"`
 
 	// Determines whether the parameter value SHOULD allow reserved characters, as defined by RFC3986 :/?#[]@!$&'()*+,;= to be included without percent-encoding.
 	// This property only applies to parameters with an in value of query.
@@ -68,6 +68,21 @@ type Header struct {
 	// The key is the media type and the value describes it.
 	// The map MUST only contain one entry.
 	Content map[string]*MediaType `json:"content,omitempty"`
+
+	// Specification extensions (x-*), inlined into object.
+	Extensions Extensions `json:"-"`
+}
+
+// MarshalJSON encodes Header with its extensions.
+func (s Header) MarshalJSON() ([]byte, error) {
+	type plain Header
+	return marshalExtensible(plain(s), s.Extensions)
+}
+
+// UnmarshalJSON decodes Header with its extensions.
+func (s *Header) UnmarshalJSON(data []byte) error {
+	type plain Header
+	return unmarshalExtensible(data, (*plain)(s), &s.Extensions)
 }
 
 // Entity satisfies componenter interface
@@ -26,4 +26,19 @@ type Info struct {
 	// REQUIRED.
 	// The version of the OpenAPI document (which is distinct from the OpenAPI Specification version or the API implementation version).
 	Version string `json:"version"`
+
+	// Specification extensions (x-*), inlined into object.
+	Extensions Extensions `json:"-"`
+}
+
+// MarshalJSON encodes Info with its extensions.
+func (s Info) MarshalJSON() ([]byte, error) {
+	type plain Info
+	return marshalExtensible(plain(s), s.Extensions)
+}
+
+// UnmarshalJSON decodes Info with its extensions.
+func (s *Info) UnmarshalJSON(data []byte) error {
+	type plain Info
+	return unmarshalExtensible(data, (*plain)(s), &s.Extensions)
 }
@@ -0,0 +1,28 @@
+package spec
+
+// License information for the exposed API.
+type License struct {
+
+	// REQUIRED.
+	// The license name used for the API.
+	Name string `json:"name"`
+
+	// A URL to the license used for the API.
+	// MUST be in the format of a URL.
+	URL string `json:"url,omitempty"`
+
+	// Specification extensions (x-*), inlined into object.
+	Extensions Extensions `json:"-"`
+}
+
+// MarshalJSON encodes License with its extensions.
+func (s License) MarshalJSON() ([]byte, error) {
+	type plain License
+	return marshalExtensible(plain(s), s.Extensions)
+}
+
+// UnmarshalJSON decodes License with its extensions.
+func (s *License) UnmarshalJSON(data []byte) error {
+	type plain License
+	return unmarshalExtensible(data, (*plain)(s), &s.Extensions)
+}
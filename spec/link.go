@@ -30,6 +30,21 @@ type Link struct {
 
 	// A server object to be used by the target operation.
 	Server *Server `json:"server,omitempty"`
+
+	// Specification extensions (x-*), inlined into object.
+	Extensions Extensions `json:"-"`
+}
+
+// MarshalJSON encodes Link with its extensions.
+func (s Link) MarshalJSON() ([]byte, error) {
+	type plain Link
+	return marshalExtensible(plain(s), s.Extensions)
+}
+
+// UnmarshalJSON decodes Link with its extensions.
+func (s *Link) UnmarshalJSON(data []byte) error {
+	type plain Link
+	return unmarshalExtensible(data, (*plain)(s), &s.Extensions)
 }
 
 // Entity satisfies componenter interface
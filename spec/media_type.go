@@ -19,4 +19,19 @@ type MediaType struct {
 	// The key, being the property name, MUST exist in the schema as a property.
 	// The encoding object SHALL only apply to requestBody objects when the media type is multipart or application/x-www-form-urlencoded.
 	Encoding map[string]*Encoding `json:"encoding,omitempty"`
+
+	// Specification extensions (x-*), inlined into object.
+	Extensions Extensions `json:"-"`
+}
+
+// MarshalJSON encodes MediaType with its extensions.
+func (s MediaType) MarshalJSON() ([]byte, error) {
+	type plain MediaType
+	return marshalExtensible(plain(s), s.Extensions)
+}
+
+// UnmarshalJSON decodes MediaType with its extensions.
+func (s *MediaType) UnmarshalJSON(data []byte) error {
+	type plain MediaType
+	return unmarshalExtensible(data, (*plain)(s), &s.Extensions)
 }
@@ -24,4 +24,19 @@ type OAuthFlow struct {
 	// The available scopes for the OAuth2 security scheme.
 	// A map between the scope name and a short description for it.
 	Acopes map[string]string `json:"acopes,omitempty"`
+
+	// Specification extensions (x-*), inlined into object.
+	Extensions Extensions `json:"-"`
+}
+
+// MarshalJSON encodes OAuthFlow with its extensions.
+func (s OAuthFlow) MarshalJSON() ([]byte, error) {
+	type plain OAuthFlow
+	return marshalExtensible(plain(s), s.Extensions)
+}
+
+// UnmarshalJSON decodes OAuthFlow with its extensions.
+func (s *OAuthFlow) UnmarshalJSON(data []byte) error {
+	type plain OAuthFlow
+	return unmarshalExtensible(data, (*plain)(s), &s.Extensions)
 }
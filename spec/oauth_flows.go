@@ -16,4 +16,19 @@ type OAuthFlows struct {
 	// Configuration for the OAuth Authorization Code flow.
 	// Previously called accessCode in OpenAPI 2.0.
 	AuthorizationCode *OAuthFlow `json:"authorizationCode,omitempty"`
+
+	// Specification extensions (x-*), inlined into object.
+	Extensions Extensions `json:"-"`
+}
+
+// MarshalJSON encodes OAuthFlows with its extensions.
+func (s OAuthFlows) MarshalJSON() ([]byte, error) {
+	type plain OAuthFlows
+	return marshalExtensible(plain(s), s.Extensions)
+}
+
+// UnmarshalJSON decodes OAuthFlows with its extensions.
+func (s *OAuthFlows) UnmarshalJSON(data []byte) error {
+	type plain OAuthFlows
+	return unmarshalExtensible(data, (*plain)(s), &s.Extensions)
 }
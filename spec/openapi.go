@@ -22,6 +22,10 @@ type OpenAPI struct {
 	// The available paths and operations for the API.
 	Paths Paths `json:"paths,omitempty"`
 
+	// The incoming webhooks that MAY be received as part of this API,
+	// supported since OpenAPI 3.1.
+	Webhooks map[string]*PathItem `json:"webhooks,omitempty"`
+
 	// An element to hold various schemas for the specification.
 	Components *Components `json:"components,omitempty"`
 
@@ -40,4 +44,19 @@ type OpenAPI struct {
 
 	// Additional extern
 	ExternalDocs *ExternalDocumentation `json:"externalDocs,omitempty"`
+
+	// Specification extensions (x-*), inlined into object.
+	Extensions Extensions `json:"-"`
+}
+
+// MarshalJSON encodes OpenAPI with its extensions.
+func (s OpenAPI) MarshalJSON() ([]byte, error) {
+	type plain OpenAPI
+	return marshalExtensible(plain(s), s.Extensions)
+}
+
+// UnmarshalJSON decodes OpenAPI with its extensions.
+func (s *OpenAPI) UnmarshalJSON(data []byte) error {
+	type plain OpenAPI
+	return unmarshalExtensible(data, (*plain)(s), &s.Extensions)
 }
@@ -0,0 +1,135 @@
+package spec
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/buypal/oapi-go/internal/container"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoundTrip(t *testing.T) {
+	files := []string{
+		"petstore.yaml",
+		"uspto.yaml",
+	}
+
+	for _, f := range files {
+		t.Run(f, func(t *testing.T) {
+			c, err := container.ReadFile(filepath.Join("testdata", f))
+			require.NoError(t, err)
+
+			// required: false is default, it is omitted
+			omitDefaults(c.Data())
+
+			var o OpenAPI
+			err = json.Unmarshal(c.Bytes(), &o)
+			require.NoError(t, err)
+
+			// json
+			data, err := json.Marshal(o)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(c.Bytes()), string(data))
+
+			// yaml
+			x, err := container.ReadJSON(data)
+			require.NoError(t, err)
+			data, err = x.MarshalYAML()
+			require.NoError(t, err)
+			y, err := container.ReadYAML(data)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(c.Bytes()), string(y.Bytes()))
+		})
+	}
+}
+
+// omitDefaults removes values which are same as default ones
+func omitDefaults(v interface{}) {
+	switch x := v.(type) {
+	case map[string]interface{}:
+		if x["required"] == false {
+			delete(x, "required")
+		}
+		for _, v := range x {
+			omitDefaults(v)
+		}
+	case []interface{}:
+		for _, v := range x {
+			omitDefaults(v)
+		}
+	}
+}
+
+func TestExtensions(t *testing.T) {
+	doc := `{
+		"openapi": "3.0.3",
+		"info": {"title": "API", "version": "1", "x-internal": true},
+		"paths": {
+			"/items": {
+				"x-amazon-apigateway-any-method": {"x-amazon-apigateway-integration": {"type": "http"}},
+				"get": {
+					"x-go-name": "ListItems",
+					"responses": {"200": {"description": "ok"}},
+					"callbacks": {
+						"done": {
+							"x-internal": false,
+							"{$request.body#/url}": {"post": {"responses": {"200": {"description": "ok"}}}}
+						}
+					}
+				}
+			}
+		},
+		"components": {
+			"schemas": {
+				"Item": {"type": "object", "x-go-name": "Item"}
+			}
+		}
+	}`
+
+	var o OpenAPI
+	err := json.Unmarshal([]byte(doc), &o)
+	require.NoError(t, err)
+	assert.Equal(t, Any("true"), o.Info.Extensions["x-internal"])
+	assert.Equal(t, Any(`"ListItems"`), o.Paths["/items"].Get.Extensions["x-go-name"])
+	assert.Equal(t, Any(`"Item"`), o.Components.Schemas["Item"].Extensions["x-go-name"])
+
+	data, err := json.Marshal(o)
+	require.NoError(t, err)
+	assert.JSONEq(t, doc, string(data))
+
+	s := Schema{Extensions: Extensions{"go-name": NewAny("Item")}}
+	_, err = json.Marshal(s)
+	assert.Error(t, err)
+
+	data, err = json.Marshal(Schema{Extensions: Extensions{"x-b": NewAny(1), "x-a": NewAny("a")}})
+	require.NoError(t, err)
+	assert.Equal(t, `{"x-a":"a","x-b":1}`, string(data))
+}
+
+func TestSchemaVersions(t *testing.T) {
+	doc := `{
+		"type": "object",
+		"properties": {
+			"a": {"type": ["string", "null"]},
+			"b": {"type": "integer", "exclusiveMinimum": 0, "exclusiveMaximum": 10.5},
+			"c": {"type": "number", "minimum": 0, "exclusiveMinimum": true}
+		}
+	}`
+
+	var s Schema
+	err := json.Unmarshal([]byte(doc), &s)
+	require.NoError(t, err)
+	assert.Equal(t, []OAPIType{TypeString, "null"}, s.Properties["a"].Types)
+	assert.Equal(t, 0.0, *s.Properties["b"].ExclusiveMinimumValue)
+	assert.Equal(t, 10.5, *s.Properties["b"].ExclusiveMaximumValue)
+	assert.True(t, s.Properties["c"].ExclusiveMinimum)
+
+	data, err := json.Marshal(s)
+	require.NoError(t, err)
+	assert.JSONEq(t, doc, string(data))
+
+	err = json.Unmarshal([]byte(`{"exclusiveMinimum": "0"}`), &s)
+	assert.Error(t, err)
+}
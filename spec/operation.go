@@ -59,4 +59,19 @@ type Operation struct {
 	// An alternative server array to service this operation.
 	// If an alternative server object is specified at the Path Item Object or Root level, it will be overridden by this value.
 	Servers []*Server `json:"servers,omitempty"`
+
+	// Specification extensions (x-*), inlined into object.
+	Extensions Extensions `json:"-"`
+}
+
+// MarshalJSON encodes Operation with its extensions.
+func (s Operation) MarshalJSON() ([]byte, error) {
+	type plain Operation
+	return marshalExtensible(plain(s), s.Extensions)
+}
+
+// UnmarshalJSON decodes Operation with its extensions.
+func (s *Operation) UnmarshalJSON(data []byte) error {
+	type plain Operation
+	return unmarshalExtensible(data, (*plain)(s), &s.Extensions)
 }
@@ -59,7 +59,7 @@ type Parameter struct {
 	// For other types of parameters this property has no effect.
 	// When style is form, the default value is true.
 	// For all other styles, the default value is false.
-	Explode bool `json:"explode"`
+	Explode *bool `json:"explode,omitempty"`
 
 	// Determines whether the parameter value SHOULD allow reserved characters, as defined by RFC3986 :/?#[]@!$&'()*+,;= to be included without percent-encoding.
 	// This property only applies to parameters with an in value of query.
@@ -90,6 +90,21 @@ type Parameter struct {
 	// The key is the media type and the value describes it.
 	// The map MUST only contain one entry.
 	Content map[string]*MediaType `json:"content,omitempty"`
+
+	// Specification extensions (x-*), inlined into object.
+	Extensions Extensions `json:"-"`
+}
+
+// MarshalJSON encodes Parameter with its extensions.
+func (s Parameter) MarshalJSON() ([]byte, error) {
+	type plain Parameter
+	return marshalExtensible(plain(s), s.Extensions)
+}
+
+// UnmarshalJSON decodes Parameter with its extensions.
+func (s *Parameter) UnmarshalJSON(data []byte) error {
+	type plain Parameter
+	return unmarshalExtensible(data, (*plain)(s), &s.Extensions)
 }
 
 // Entity satisfies componenter interface
@@ -51,6 +51,21 @@ type PathItem struct {
 	// A unique parameter is defined by a combination of a name and location.
 	// The list can use the Reference Object to link to parameters that are defined at the OpenAPI Object's components/parameters.
 	Parameters []*Parameter `json:"parameters,omitempty"`
+
+	// Specification extensions (x-*), inlined into object.
+	Extensions Extensions `json:"-"`
+}
+
+// MarshalJSON encodes PathItem with its extensions.
+func (s PathItem) MarshalJSON() ([]byte, error) {
+	type plain PathItem
+	return marshalExtensible(plain(s), s.Extensions)
+}
+
+// UnmarshalJSON decodes PathItem with its extensions.
+func (s *PathItem) UnmarshalJSON(data []byte) error {
+	type plain PathItem
+	return unmarshalExtensible(data, (*plain)(s), &s.Extensions)
 }
 
 // Operations returns all operations as a array
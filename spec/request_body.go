@@ -14,11 +14,26 @@ type RequestBody struct {
 	// The key is a media type or media type range and the value describes it.
 	// For requests that match multiple keys, only the most specific key is applicable.
 	// e.g. text/plain overrides text/*
-	Content map[string]*MediaType `json:"content"`
+	Content map[string]*MediaType `json:"content,omitempty"`
 
 	// Determines if the request body is required in the request.
 	// Defaults to false.
 	Required bool `json:"required,omitempty"`
+
+	// Specification extensions (x-*), inlined into object.
+	Extensions Extensions `json:"-"`
+}
+
+// MarshalJSON encodes RequestBody with its extensions.
+func (s RequestBody) MarshalJSON() ([]byte, error) {
+	type plain RequestBody
+	return marshalExtensible(plain(s), s.Extensions)
+}
+
+// UnmarshalJSON decodes RequestBody with its extensions.
+func (s *RequestBody) UnmarshalJSON(data []byte) error {
+	type plain RequestBody
+	return unmarshalExtensible(data, (*plain)(s), &s.Extensions)
 }
 
 // Entity satisfies componenter interface
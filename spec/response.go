@@ -18,11 +18,26 @@ type Response struct {
 	// The key is a media type or media type range and the value describes it.
 	// For responses that match multiple keys, only the most specific key is applicable.
 	// e.g. text/plain overrides text/*
-	Content map[string]*MediaType `json:"content"`
+	Content map[string]*MediaType `json:"content,omitempty"`
 
 	// A map of operations links that can be followed from the response.
 	// The key of the map is a short name for the link, following the naming constraints of the names for Component Objects.
 	Links map[string]*Link `json:"links,omitempty"`
+
+	// Specification extensions (x-*), inlined into object.
+	Extensions Extensions `json:"-"`
+}
+
+// MarshalJSON encodes Response with its extensions.
+func (s Response) MarshalJSON() ([]byte, error) {
+	type plain Response
+	return marshalExtensible(plain(s), s.Extensions)
+}
+
+// UnmarshalJSON decodes Response with its extensions.
+func (s *Response) UnmarshalJSON(data []byte) error {
+	type plain Response
+	return unmarshalExtensible(data, (*plain)(s), &s.Extensions)
 }
 
 // Entity satisfies componenter interface
@@ -1,5 +1,10 @@
 package spec
 
+import (
+	"encoding/json"
+	"fmt"
+)
+
 // Schema The Schema Object allows the definition of input and output data types.
 // These types can be objects, but also primitives and arrays.
 // This object is an extended subset of the JSON Schema Specification Wright Draft 00.
@@ -45,6 +50,9 @@ type Schema struct {
 	// To represent examples that cannot be naturally represented in JSON or YAML, a string value can be used to contain the example with escaping where necessary.
 	Example Any `json:"example,omitempty"`
 
+	// Examples of instances, JSON Schema keyword replacing example since OpenAPI 3.1.
+	Examples []Any `json:"examples,omitempty"`
+
 	// Specifies that a schema is deprecated and SHOULD be transitioned out of usage.
 	// Default value is false.
 	Deprecated bool `json:"deprecated,omitempty"`
@@ -60,6 +68,12 @@ type Schema struct {
 	Minimum          *float64 `json:"minimum,omitempty"`
 	ExclusiveMinimum bool     `json:"exclusiveMinimum,omitempty"`
 
+	// Exclusive bounds since OpenAPI 3.1, they are numbers instead of
+	// modifiers of maximum and minimum. When set, they take precedence
+	// over ExclusiveMaximum and ExclusiveMinimum.
+	ExclusiveMaximumValue *float64 `json:"-"`
+	ExclusiveMinimumValue *float64 `json:"-"`
+
 	// Strings
 	MaxLength *int64 `json:"maxLength,omitempty"`
 	MinLength *int64 `json:"minLength,omitempty"`
@@ -75,15 +89,30 @@ type Schema struct {
 	MinProperties *int64   `json:"minProperties,omitempty"`
 	Required      []string `json:"required,omitempty"`
 
+	// Schema which names of properties must match, this is JSON Schema
+	// keyword used to describe keys of maps.
+	PropertyNames *Schema `json:"propertyNames,omitempty"`
+
 	// All
 	Enum []Any `json:"enum,omitempty"`
 
+	// Value of instance has to be equal to const, supported since OpenAPI 3.1.
+	Const Any `json:"const,omitempty"`
+
+	// Schemas which can be referenced from within this schema,
+	// supported since OpenAPI 3.1.
+	Defs map[string]*Schema `json:"$defs,omitempty"`
+
 	// The following properties are taken from the JSON Schema definition but their definitions were adjusted to the OpenAPI Specification.
 
 	// Value MUST be a string.
 	// Multiple types via an array are not supported.
 	Type OAPIType `json:"type,omitempty"`
 
+	// Types of schema since OpenAPI 3.1, where type can be an array,
+	// ie. [string, null]. When set, it takes precedence over Type.
+	Types []OAPIType `json:"-"`
+
 	// Inline or referenced schema MUST be of a Schema Object and not a standard JSON Schema.
 	AllOf []*Schema `json:"allOf,omitempty"`
 
@@ -119,6 +148,88 @@ type Schema struct {
 	// Unlike JSON Schema, the value MUST conform to the defined type for the Schema Object defined at the same level.
 	// For example, if type is string, then default can be "foo" but cannot be 1.
 	Default Any `json:"default,omitempty"`
+
+	// Specification extensions (x-*), inlined into object.
+	Extensions Extensions `json:"-"`
+}
+
+// MarshalJSON encodes Schema with its extensions.
+func (s Schema) MarshalJSON() ([]byte, error) {
+	type plain Schema
+	// keywords having different form in OpenAPI 3.0 and 3.1
+	x := struct {
+		plain
+		Type             interface{} `json:"type,omitempty"`
+		ExclusiveMaximum interface{} `json:"exclusiveMaximum,omitempty"`
+		ExclusiveMinimum interface{} `json:"exclusiveMinimum,omitempty"`
+	}{plain: plain(s)}
+
+	switch {
+	case len(s.Types) > 0:
+		x.Type = s.Types
+	case len(s.Type) > 0:
+		x.Type = s.Type
+	}
+	x.ExclusiveMaximum = exclusiveBound(s.ExclusiveMaximum, s.ExclusiveMaximumValue)
+	x.ExclusiveMinimum = exclusiveBound(s.ExclusiveMinimum, s.ExclusiveMinimumValue)
+	return marshalExtensible(x, s.Extensions)
+}
+
+// UnmarshalJSON decodes Schema with its extensions.
+func (s *Schema) UnmarshalJSON(data []byte) error {
+	type plain Schema
+	x := struct {
+		*plain
+		Type             json.RawMessage `json:"type,omitempty"`
+		ExclusiveMaximum json.RawMessage `json:"exclusiveMaximum,omitempty"`
+		ExclusiveMinimum json.RawMessage `json:"exclusiveMinimum,omitempty"`
+	}{plain: (*plain)(s)}
+
+	err := unmarshalExtensible(data, &x, &s.Extensions)
+	if err != nil {
+		return err
+	}
+
+	if len(x.Type) > 0 && x.Type[0] == '[' {
+		err = json.Unmarshal(x.Type, &s.Types)
+	} else if len(x.Type) > 0 {
+		err = json.Unmarshal(x.Type, &s.Type)
+	}
+	if err != nil {
+		return fmt.Errorf("spec: type: %w", err)
+	}
+	err = unmarshalExclusiveBound(x.ExclusiveMaximum, &s.ExclusiveMaximum, &s.ExclusiveMaximumValue)
+	if err != nil {
+		return fmt.Errorf("spec: exclusiveMaximum: %w", err)
+	}
+	err = unmarshalExclusiveBound(x.ExclusiveMinimum, &s.ExclusiveMinimum, &s.ExclusiveMinimumValue)
+	if err != nil {
+		return fmt.Errorf("spec: exclusiveMinimum: %w", err)
+	}
+	return nil
+}
+
+// exclusiveBound returns exclusive bound as number (3.1) or as flag (3.0)
+func exclusiveBound(flag bool, value *float64) interface{} {
+	switch {
+	case value != nil:
+		return *value
+	case flag:
+		return true
+	}
+	return nil
+}
+
+// unmarshalExclusiveBound decodes exclusive bound given either as flag
+// or as number
+func unmarshalExclusiveBound(data json.RawMessage, flag *bool, value **float64) error {
+	if len(data) == 0 || string(data) == "null" {
+		return nil
+	}
+	if data[0] == 't' || data[0] == 'f' {
+		return json.Unmarshal(data, flag)
+	}
+	return json.Unmarshal(data, value)
 }
 
 // Entity satisfies componenter interface
@@ -38,6 +38,21 @@ type SecurityScheme struct {
 	// OpenId Connect URL to discover OAuth2 configuration values.
 	// This MUST be in the form of a URL.
 	OpenIDConnectURL string `json:"openIdConnectUrl,omitempty"`
+
+	// Specification extensions (x-*), inlined into object.
+	Extensions Extensions `json:"-"`
+}
+
+// MarshalJSON encodes SecurityScheme with its extensions.
+func (s SecurityScheme) MarshalJSON() ([]byte, error) {
+	type plain SecurityScheme
+	return marshalExtensible(plain(s), s.Extensions)
+}
+
+// UnmarshalJSON decodes SecurityScheme with its extensions.
+func (s *SecurityScheme) UnmarshalJSON(data []byte) error {
+	type plain SecurityScheme
+	return unmarshalExtensible(data, (*plain)(s), &s.Extensions)
 }
 
 // Entity satisfies componenter interface
@@ -16,4 +16,19 @@ type Server struct {
 	// A map between a variable name and its value.
 	// The value is used for substitution in the server's URL template.
 	Variables map[string]*ServerVariable `json:"variables,omitempty"`
+
+	// Specification extensions (x-*), inlined into object.
+	Extensions Extensions `json:"-"`
+}
+
+// MarshalJSON encodes Server with its extensions.
+func (s Server) MarshalJSON() ([]byte, error) {
+	type plain Server
+	return marshalExtensible(plain(s), s.Extensions)
+}
+
+// UnmarshalJSON decodes Server with its extensions.
+func (s *Server) UnmarshalJSON(data []byte) error {
+	type plain Server
+	return unmarshalExtensible(data, (*plain)(s), &s.Extensions)
 }
@@ -14,4 +14,19 @@ type ServerVariable struct {
 	// An optional description for the server variable.
 	// CommonMark syntax MAY be used for rich text representation.
 	Description string `json:"description,omitempty"`
+
+	// Specification extensions (x-*), inlined into object.
+	Extensions Extensions `json:"-"`
+}
+
+// MarshalJSON encodes ServerVariable with its extensions.
+func (s ServerVariable) MarshalJSON() ([]byte, error) {
+	type plain ServerVariable
+	return marshalExtensible(plain(s), s.Extensions)
+}
+
+// UnmarshalJSON decodes ServerVariable with its extensions.
+func (s *ServerVariable) UnmarshalJSON(data []byte) error {
+	type plain ServerVariable
+	return unmarshalExtensible(data, (*plain)(s), &s.Extensions)
 }
@@ -14,4 +14,19 @@ type Tag struct {
 
 	// Additional external documentation for this tag
 	ExternalDocs *ExternalDocumentation `json:"externalDocs,omitempty"`
+
+	// Specification extensions (x-*), inlined into object.
+	Extensions Extensions `json:"-"`
+}
+
+// MarshalJSON encodes Tag with its extensions.
+func (s Tag) MarshalJSON() ([]byte, error) {
+	type plain Tag
+	return marshalExtensible(plain(s), s.Extensions)
+}
+
+// UnmarshalJSON decodes Tag with its extensions.
+func (s *Tag) UnmarshalJSON(data []byte) error {
+	type plain Tag
+	return unmarshalExtensible(data, (*plain)(s), &s.Extensions)
 }
@@ -9,7 +9,7 @@ type XML struct {
 	// When defined within items, it will affect the name of the individual XML elements within the list.
 	// When defined alongside type being array (outside the items), it will affect the wrapping element and only if wrapped is true.
 	// If wrapped is false, it will be ignored.
-	Name string `json:"name"`
+	Name string `json:"name,omitempty"`
 
 	// The URI of the namespace definition.
 	// Value MUST be in the form of an absolute URI.
@@ -27,6 +27,25 @@ type XML struct {
 	// Default value is false.
 	// The definition takes effect only when defined alongside type being array (outside the items).
 	Wrapped bool `json:"wrapped,omitempty"`
+
+	// Vendor extension declaring that property is character data of element,
+	// not an element of its own. OpenAPI has no way to describe text nodes.
+	Text bool `json:"x-text,omitempty"`
+
+	// Specification extensions (x-*), inlined into object.
+	Extensions Extensions `json:"-"`
+}
+
+// MarshalJSON encodes XML with its extensions.
+func (s XML) MarshalJSON() ([]byte, error) {
+	type plain XML
+	return marshalExtensible(plain(s), s.Extensions)
+}
+
+// UnmarshalJSON decodes XML with its extensions.
+func (s *XML) UnmarshalJSON(data []byte) error {
+	type plain XML
+	return unmarshalExtensible(data, (*plain)(s), &s.Extensions)
 }
 
 // WithName sets the xml name for the object
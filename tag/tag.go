@@ -2,6 +2,7 @@ package tag
 
 import (
 	"strconv"
+	"strings"
 
 	"github.com/fatih/structtag"
 	"github.com/vmihailenco/tagparser"
@@ -11,30 +12,75 @@ import (
 // which might be handy in both validation & schema attributes supply.
 // It is not intended to contain whole scheme definition.
 type Tag struct {
-	Ignore     bool
-	Inline     *bool
-	OmitEmpty  bool
-	Nullable   *bool
-	ReadOnly   bool
-	WriteOnly  bool
-	Deprecated bool
-	Required   bool
-	Name       string
-	Pattern    string
-	Format     string
-	Type       string
-	Min        *float64
-	Max        *float64
-	EMin       *float64
-	EMax       *float64
-	MulOf      *float64
-	MinLen     *int64
-	MaxLen     *int64
-	MinItems   *int64
-	MaxItems   *int64
-	UniqItems  bool
-	MinProps   *int64
-	MaxProps   *int64
+	Ignore      bool
+	Inline      *bool
+	Compose     *bool
+	OmitEmpty   bool
+	Nullable    *bool
+	ReadOnly    bool
+	WriteOnly   bool
+	Deprecated  bool
+	Required    bool
+	String      bool
+	Name        string
+	Pattern     string
+	Format      string
+	Type        string
+	Min         *float64
+	Max         *float64
+	EMin        *float64
+	EMax        *float64
+	MulOf       *float64
+	MinLen      *int64
+	MaxLen      *int64
+	MinItems    *int64
+	MaxItems    *int64
+	UniqItems   bool
+	MinProps    *int64
+	MaxProps    *int64
+	Title       string
+	Description string
+	Example     *string
+	Default     *string
+	Enum        []string
+	XML         *XML
+	Proto       *Proto
+	// Extensions are x-* options, ie. x-go-name=Foo
+	Extensions map[string]string
+
+	// NotRequired is set by required:false, field is then
+	// not required even if it would be inferred as required.
+	NotRequired bool
+}
+
+// Proto represents protobuf tag of fields generated by protoc-gen-go.
+type Proto struct {
+	Name     string
+	JSONName string
+	// Oneof is set for fields of oneof wrappers.
+	Oneof bool
+	// OneofName is set for fields holding oneof wrappers.
+	OneofName string
+}
+
+// JSON returns name of field used by protojson
+func (p Proto) JSON() string {
+	if len(p.JSONName) > 0 {
+		return p.JSONName
+	}
+	return p.Name
+}
+
+// XML represents xml tag as understood by encoding/xml.
+type XML struct {
+	Name      string
+	Namespace string
+	// Parents are names of elements wrapping element, ie. a and b for a>b>c.
+	Parents  []string
+	Attr     bool
+	CharData bool
+	InnerXML bool
+	Ignore   bool
 }
 
 // Parse will parse all fileds and tags
@@ -52,14 +98,17 @@ func Parse(rawtags string) (meta Tag, err error) {
 	if err = parseOAPITag(tags, &meta); err != nil {
 		return
 	}
+	parseXMLTag(tags, &meta)
+	parseProtoTag(tags, &meta)
 	return
 }
 
 func parseOAPITag(tags *structtag.Tags, meta *Tag) (err error) {
-	tag := parseTag(tags, "oapi")
-	if tag == nil {
+	raw, err := tags.Get("oapi")
+	if err != nil {
 		return nil
 	}
+	tag := tagparser.Parse(normalizeOptions(raw.Value()))
 	if tag.Name == "-" {
 		meta.Ignore = true
 		return
@@ -69,13 +118,16 @@ func parseOAPITag(tags *structtag.Tags, meta *Tag) (err error) {
 	}
 	errs := []error{
 		parseTagBoolPtr(tag, "inline", &meta.Inline),
-		parseTagBool(tag, "omitempty", &meta.OmitEmpty),
+		parseTagBoolPtr(tag, "compose", &meta.Compose),
+		parseTagBoolOr(tag, "omitempty", &meta.OmitEmpty),
 		parseTagBoolPtr(tag, "nullable", &meta.Nullable),
 		parseTagBool(tag, "readonly", &meta.ReadOnly),
 		parseTagBool(tag, "writeonly", &meta.WriteOnly),
 		parseTagBool(tag, "deprecated", &meta.Deprecated),
 		parseTagBool(tag, "unique", &meta.UniqItems),
 		parseTagBool(tag, "required", &meta.Required),
+		parseTagFalse(tag, "required", &meta.NotRequired),
+		parseTagBoolOr(tag, "string", &meta.String),
 		parseTagIntPtr(tag, "maxlen", &meta.MaxLen),
 		parseTagIntPtr(tag, "minlen", &meta.MinLen),
 		parseTagIntPtr(tag, "maxitems", &meta.MaxItems),
@@ -85,6 +137,11 @@ func parseOAPITag(tags *structtag.Tags, meta *Tag) (err error) {
 		parseTagString(tag, "pattern", &meta.Pattern),
 		parseTagString(tag, "format", &meta.Format),
 		parseTagString(tag, "type", &meta.Type),
+		parseTagString(tag, "title", &meta.Title),
+		parseTagString(tag, "description", &meta.Description),
+		parseTagStringPtr(tag, "example", &meta.Example),
+		parseTagStringPtr(tag, "default", &meta.Default),
+		parseTagList(tag, "enum", &meta.Enum),
 		parseTagFloatPtr(tag, "max", &meta.Max),
 		parseTagFloatPtr(tag, "min", &meta.Min),
 		parseTagFloatPtr(tag, "emax", &meta.EMax),
@@ -96,9 +153,23 @@ func parseOAPITag(tags *structtag.Tags, meta *Tag) (err error) {
 			return err
 		}
 	}
+	parseTagExtensions(tag, &meta.Extensions)
 	return
 }
 
+// parseTagExtensions collects x-* options, ie. x-go-name:Foo
+func parseTagExtensions(m *tagparser.Tag, val *map[string]string) {
+	for k, v := range m.Options {
+		if !strings.HasPrefix(k, "x-") {
+			continue
+		}
+		if *val == nil {
+			*val = make(map[string]string)
+		}
+		(*val)[k] = v
+	}
+}
+
 func parseJSONTag(tags *structtag.Tags, meta *Tag) (err error) {
 	tag := parseTag(tags, "json")
 	if tag == nil {
@@ -114,6 +185,7 @@ func parseJSONTag(tags *structtag.Tags, meta *Tag) (err error) {
 	errs := []error{
 		parseTagBoolPtr(tag, "inline", &meta.Inline),
 		parseTagBool(tag, "omitempty", &meta.OmitEmpty),
+		parseTagBool(tag, "string", &meta.String),
 	}
 	for _, err := range errs {
 		if err != nil {
@@ -123,6 +195,61 @@ func parseJSONTag(tags *structtag.Tags, meta *Tag) (err error) {
 	return
 }
 
+func parseXMLTag(tags *structtag.Tags, meta *Tag) {
+	tag, err := tags.Get("xml")
+	if err != nil {
+		return
+	}
+	x := &XML{}
+	if tag.Name == "-" {
+		x.Ignore = true
+		meta.XML = x
+		return
+	}
+	name := tag.Name
+	// namespace is separated from name by space
+	if i := strings.LastIndex(name, " "); i >= 0 {
+		x.Namespace, name = name[:i], name[i+1:]
+	}
+	path := strings.Split(name, ">")
+	x.Name = path[len(path)-1]
+	x.Parents = path[:len(path)-1]
+	for _, o := range tag.Options {
+		switch o {
+		case "attr":
+			x.Attr = true
+		case "chardata", "cdata":
+			x.CharData = true
+		case "innerxml":
+			x.InnerXML = true
+		}
+	}
+	meta.XML = x
+}
+
+func parseProtoTag(tags *structtag.Tags, meta *Tag) {
+	if tag, err := tags.Get("protobuf_oneof"); err == nil {
+		meta.Proto = &Proto{OneofName: tag.Name}
+		return
+	}
+	tag, err := tags.Get("protobuf")
+	if err != nil {
+		return
+	}
+	p := &Proto{}
+	for _, o := range append([]string{tag.Name}, tag.Options...) {
+		switch {
+		case strings.HasPrefix(o, "name="):
+			p.Name = strings.TrimPrefix(o, "name=")
+		case strings.HasPrefix(o, "json="):
+			p.JSONName = strings.TrimPrefix(o, "json=")
+		case o == "oneof":
+			p.Oneof = true
+		}
+	}
+	meta.Proto = p
+}
+
 func parseTag(tags *structtag.Tags, tag string) (result *tagparser.Tag) {
 	oapi, err := tags.Get(tag)
 	if err != nil {
@@ -184,12 +311,41 @@ func parseTagIntPtr(m *tagparser.Tag, tag string, val **int64) error {
 	return nil
 }
 
+// parseTagString parses string value, value might be quoted by
+// single quotes if it contains comma, ie. example:'a, b'.
 func parseTagString(m *tagparser.Tag, tag string, val *string) error {
 	v, ok := m.Options[tag]
 	if !ok {
 		return nil
 	}
-	*val = v
+	*val, _ = tagparser.Unquote(v)
+	return nil
+}
+
+func parseTagStringPtr(m *tagparser.Tag, tag string, val **string) error {
+	var v string
+	if _, ok := m.Options[tag]; !ok {
+		return nil
+	}
+	err := parseTagString(m, tag, &v)
+	if err != nil {
+		return err
+	}
+	*val = &v
+	return nil
+}
+
+// parseTagList parses list of values separated by "|"
+func parseTagList(m *tagparser.Tag, tag string, val *[]string) error {
+	var v string
+	if _, ok := m.Options[tag]; !ok {
+		return nil
+	}
+	err := parseTagString(m, tag, &v)
+	if err != nil {
+		return err
+	}
+	*val = strings.Split(v, "|")
 	return nil
 }
 
@@ -203,6 +359,67 @@ func parseTagBool(m *tagparser.Tag, tag string, val *bool) (err error) {
 	return nil
 }
 
+// parseTagBoolOr is same as parseTagBool except it keeps value
+// if option is not present, so it can override option from other tag.
+func parseTagBoolOr(m *tagparser.Tag, tag string, val *bool) (err error) {
+	if _, ok := m.Options[tag]; !ok {
+		return nil
+	}
+	return parseTagBool(m, tag, val)
+}
+
+// parseTagFalse sets val if option is present and it is false
+func parseTagFalse(m *tagparser.Tag, tag string, val *bool) (err error) {
+	x, ok := m.Options[tag]
+	if !ok || x == "" {
+		return nil
+	}
+	y, err := strconv.ParseBool(x)
+	if err != nil {
+		return
+	}
+	*val = !y
+	return nil
+}
+
+// normalizeOptions rewrites options given as key=value into key:value
+// form understood by tagparser, ie. required=false or x-go-name=Foo.
+// Quoted values are kept as they are.
+func normalizeOptions(s string) string {
+	b := []byte(s)
+	quoted, start := false, true
+	for i := 0; i < len(b); i++ {
+		switch c := b[i]; {
+		case c == '\'':
+			quoted = !quoted
+		case quoted:
+		case c == ',':
+			start = true
+			continue
+		case start && c == ' ':
+			continue
+		case start:
+			j := i
+			for j < len(b) && isKeyChar(b[j]) {
+				j++
+			}
+			if j > i && j < len(b) && b[j] == '=' {
+				b[j] = ':'
+			}
+			if j > i {
+				i = j - 1
+			}
+		}
+		start = false
+	}
+	return string(b)
+}
+
+func isKeyChar(c byte) bool {
+	return c == '-' || c == '_' ||
+		(c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
 func parseTagBoolPtr(m *tagparser.Tag, tag string, val **bool) (err error) {
 	x, ok := m.Options[tag]
 	if !ok {
@@ -0,0 +1,18 @@
+package autoexport
+
+// Address is referenced twice.
+type Address struct {
+	City string `json:"city"`
+}
+
+// Customer has billing and shipping address.
+type Customer struct {
+	Billing  Address `json:"billing"`
+	Shipping Address `json:"shipping"`
+}
+
+// Category is recursive.
+type Category struct {
+	Name     string     `json:"name"`
+	Children []Category `json:"children"`
+}
@@ -0,0 +1,7 @@
+// Package badref refers to schema of unknown scheme.
+package badref
+
+// Event is valid.
+type Event struct {
+	Name string `json:"name"`
+}
@@ -0,0 +1,7 @@
+package badtype
+
+// Event can not be described, channels have no json encoding.
+type Event struct {
+	Name    string   `json:"name"`
+	Updates chan int `json:"updates"`
+}
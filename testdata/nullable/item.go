@@ -0,0 +1,9 @@
+package nullable
+
+//openapi:schema Item
+
+// Item is nullable item.
+type Item struct {
+	Name  *string `json:"name"`
+	Count int     `json:"count"`
+}
@@ -0,0 +1,11 @@
+package operation
+
+import "net/http"
+
+// File is stored file.
+type File struct {
+	Name string `json:"name"`
+}
+
+//openapi:operation GET /v1/files/{name}.json response 200 File
+func GetFile(w http.ResponseWriter, r *http.Request) {}
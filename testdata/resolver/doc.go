@@ -0,0 +1,2 @@
+// Package resolver refers to schemas of custom schemes.
+package resolver